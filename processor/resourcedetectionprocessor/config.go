@@ -15,12 +15,44 @@
 package resourcedetectionprocessor
 
 import (
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/eks"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure/aks"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/cloudinit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/digitalocean"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/docker"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/envfile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/exec"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp/gce"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/hetzner"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/httpendpoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/ibmcloud"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/oci"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/podassociation"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/scaleway"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/static"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/tencentcloud"
+)
+
+const (
+	// onErrorFail fails the processor start if detection fails.
+	onErrorFail = "fail"
+	// onErrorIgnore starts the processor without a detected resource if detection fails.
+	onErrorIgnore = "ignore"
+	// onErrorRetryInBackground starts the processor without a detected resource if detection
+	// fails, and keeps retrying the detection until it succeeds.
+	onErrorRetryInBackground = "retry_in_background"
 )
 
 // Config defines configuration for Resource processor.
@@ -36,21 +68,219 @@ type Config struct {
 	// Override indicates whether any existing resource attributes
 	// should be overridden or preserved. Defaults to true.
 	Override bool `mapstructure:"override"`
+	// OverrideAttributes lists resource attributes whose detected values always replace
+	// the existing ones, even when Override is false.
+	OverrideAttributes []string `mapstructure:"override_attributes"`
+	// DetectConcurrently indicates whether the detectors should be run in
+	// parallel rather than one after another. Results are still merged in
+	// the order the detectors are listed. Defaults to false.
+	DetectConcurrently bool `mapstructure:"detect_concurrently"`
+	// RefreshInterval specifies how often the detectors should be re-run in the
+	// background to pick up changes in the detected resource. A value of zero
+	// disables refreshing, so detection only happens once at startup. Defaults to 0.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+	// DetectOnFirstBatch indicates whether detection should be deferred from the processor start
+	// to the arrival of the first batch of data, so that the collector starts even if metadata
+	// services are not available yet. Detection failures then leave the data unchanged instead of
+	// failing the start. Defaults to false.
+	DetectOnFirstBatch bool `mapstructure:"detect_on_first_batch"`
+	// OnError specifies what happens if detection fails: "fail" fails the processor start,
+	// "ignore" passes data through unchanged, and "retry_in_background" passes data through
+	// unchanged until a retried detection succeeds. Defaults to "fail".
+	OnError string `mapstructure:"on_error"`
+	// DebugEndpoint is the address of an HTTP endpoint serving the detected resource, along with
+	// the detector that supplied each attribute, on /debug/resourcedetection. Disabled if empty.
+	DebugEndpoint string `mapstructure:"debug_endpoint"`
+	// SemconvVersion is the semantic conventions version detected attribute names are
+	// converted to, e.g. renaming cloud.zone to cloud.availability_zone for "1.0.0".
+	// The built-in detectors already emit the 1.0.0 names, so only the attributes named
+	// by the user, e.g. through the env or static detectors, are affected. Disabled by default.
+	SemconvVersion string `mapstructure:"semconv_version"`
+	// AttributeTransforms are transformations such as hashing applied to the detected
+	// attributes, after the semantic conventions conversion.
+	AttributeTransforms []internal.AttributeTransform `mapstructure:"attribute_transforms"`
+	// AttributeConversions convert the values of detected attributes to other types, after
+	// the attribute transforms.
+	AttributeConversions []internal.AttributeConversion `mapstructure:"attribute_conversions"`
+	// Cache configures persisting the detected resource to a storage extension.
+	Cache CacheConfig `mapstructure:"cache"`
+	// PodAssociation configures adding the attributes of the Kubernetes pod that sent a batch
+	// of data, resolved from the client IP, for gateway collectors.
+	PodAssociation podassociation.Config `mapstructure:"pod_association"`
+	// EntityEvents configures emitting the detected resource as entity state events
+	// in logs pipelines.
+	EntityEvents EntityEventsConfig `mapstructure:"entity_events"`
 	// DetectorConfig is a list of settings specific to all detectors
 	DetectorConfig DetectorConfig `mapstructure:",squash"`
 }
 
+// Validate checks the settings that do not depend on the environment the detectors run in,
+// so that invalid values are reported when the configuration is loaded.
+func (cfg *Config) Validate() error {
+	switch cfg.OnError {
+	case "", onErrorFail, onErrorIgnore, onErrorRetryInBackground:
+	default:
+		return fmt.Errorf("invalid on_error mode %q, must be one of %q, %q or %q", cfg.OnError, onErrorFail, onErrorIgnore, onErrorRetryInBackground)
+	}
+
+	if _, err := internal.AttributeRenames(cfg.SemconvVersion); err != nil {
+		return err
+	}
+	if err := internal.ValidateAttributeTransforms(cfg.AttributeTransforms); err != nil {
+		return err
+	}
+	if err := internal.ValidateAttributeConversions(cfg.AttributeConversions); err != nil {
+		return err
+	}
+
+	_, err := cfg.Cache.storageID()
+	return err
+}
+
+// CacheConfig configures persisting the detected resource to a storage extension, so that
+// it can be reused after a restart if detection fails.
+type CacheConfig struct {
+	// Enabled indicates whether the detected resource should be cached. Requires
+	// a storage extension to be configured. Defaults to false.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxAge is the maximum age of a cached resource that will still be used.
+	// A value of zero means that the cached resource never expires. Defaults to 0.
+	MaxAge time.Duration `mapstructure:"max_age"`
+	// Storage is the ID of the storage extension the resource is persisted to, e.g.
+	// "file_storage/resourcedetection". May be omitted if only one storage extension is configured.
+	Storage string `mapstructure:"storage"`
+}
+
+// storageID returns the ID of the configured storage extension, or nil if none is configured.
+func (c CacheConfig) storageID() (*config.ComponentID, error) {
+	if c.Storage == "" {
+		return nil, nil
+	}
+	id, err := config.IDFromString(c.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache storage %q: %w", c.Storage, err)
+	}
+	return &id, nil
+}
+
 // DetectorConfig contains user-specified configurations unique to all individual detectors
 type DetectorConfig struct {
 	// EC2Config contains user-specified configurations for the EC2 detector
 	EC2Config ec2.Config `mapstructure:"ec2"`
+
+	// EKSConfig contains user-specified configurations for the EKS detector
+	EKSConfig eks.Config `mapstructure:"eks"`
+
+	// AzureConfig contains user-specified configurations for the Azure detector
+	AzureConfig azure.Config `mapstructure:"azure"`
+
+	// AKSConfig contains user-specified configurations for the AKS detector
+	AKSConfig aks.Config `mapstructure:"aks"`
+
+	// GCEConfig contains user-specified configurations for the GCE detector
+	GCEConfig gce.Config `mapstructure:"gce"`
+
+	// DockerConfig contains user-specified configurations for the Docker detector
+	DockerConfig docker.Config `mapstructure:"docker"`
+
+	// EnvFileConfig contains user-specified configurations for the envfile detector
+	EnvFileConfig envfile.Config `mapstructure:"envfile"`
+
+	// ExecConfig contains user-specified configurations for the exec detector
+	ExecConfig exec.Config `mapstructure:"exec"`
+
+	// K8sNodeConfig contains user-specified configurations for the Kubernetes node detector
+	K8sNodeConfig k8snode.Config `mapstructure:"k8snode"`
+
+	// OpenShiftConfig contains user-specified configurations for the OpenShift detector
+	OpenShiftConfig openshift.Config `mapstructure:"openshift"`
+
+	// StaticConfig contains user-specified configurations for the static detector
+	StaticConfig static.Config `mapstructure:"static"`
+
+	// SystemConfig contains user-specified configurations for the System detector
+	SystemConfig system.Config `mapstructure:"system"`
+
+	// HetznerConfig contains user-specified configurations for the Hetzner Cloud detector
+	HetznerConfig hetzner.Config `mapstructure:"hetzner"`
+
+	// OCIConfig contains user-specified configurations for the Oracle Cloud Infrastructure detector
+	OCIConfig oci.Config `mapstructure:"oci"`
+
+	// IBMCloudConfig contains user-specified configurations for the IBM Cloud detector
+	IBMCloudConfig ibmcloud.Config `mapstructure:"ibmcloud"`
+
+	// DigitalOceanConfig contains user-specified configurations for the DigitalOcean detector
+	DigitalOceanConfig digitalocean.Config `mapstructure:"digitalocean"`
+
+	// ConsulConfig contains user-specified configurations for the Consul detector
+	ConsulConfig consul.Config `mapstructure:"consul"`
+
+	// ScalewayConfig contains user-specified configurations for the Scaleway detector
+	ScalewayConfig scaleway.Config `mapstructure:"scaleway"`
+
+	// TencentCloudConfig contains user-specified configurations for the Tencent Cloud CVM detector
+	TencentCloudConfig tencentcloud.Config `mapstructure:"tencentcloud_cvm"`
+
+	// HTTPConfig contains user-specified configurations for the HTTP detector
+	HTTPConfig httpendpoint.Config `mapstructure:"http"`
+
+	// CloudInitConfig contains user-specified configurations for the cloud-init detector
+	CloudInitConfig cloudinit.Config `mapstructure:"cloudinit"`
+
+	// DetectorSettings contains settings that apply to any detector, keyed by detector name
+	DetectorSettings map[string]internal.DetectorSettings `mapstructure:"detector_settings"`
 }
 
 func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) internal.DetectorConfig {
 	switch detectorType {
 	case ec2.TypeStr:
 		return d.EC2Config
+	case eks.TypeStr:
+		return d.EKSConfig
+	case azure.TypeStr:
+		return d.AzureConfig
+	case aks.TypeStr:
+		return d.AKSConfig
+	case gce.TypeStr:
+		return d.GCEConfig
+	case docker.TypeStr:
+		return d.DockerConfig
+	case envfile.TypeStr:
+		return d.EnvFileConfig
+	case exec.TypeStr:
+		return d.ExecConfig
+	case k8snode.TypeStr:
+		return d.K8sNodeConfig
+	case openshift.TypeStr:
+		return d.OpenShiftConfig
+	case static.TypeStr:
+		return d.StaticConfig
+	case system.TypeStr:
+		return d.SystemConfig
+	case hetzner.TypeStr:
+		return d.HetznerConfig
+	case oci.TypeStr:
+		return d.OCIConfig
+	case ibmcloud.TypeStr:
+		return d.IBMCloudConfig
+	case digitalocean.TypeStr:
+		return d.DigitalOceanConfig
+	case consul.TypeStr:
+		return d.ConsulConfig
+	case scaleway.TypeStr:
+		return d.ScalewayConfig
+	case tencentcloud.TypeStr:
+		return d.TencentCloudConfig
+	case httpendpoint.TypeStr:
+		return d.HTTPConfig
+	case cloudinit.TypeStr:
+		return d.CloudInitConfig
 	default:
 		return nil
 	}
 }
+
+func (d *DetectorConfig) GetSettingsFromType(detectorType internal.DetectorType) internal.DetectorSettings {
+	return d.DetectorSettings[string(detectorType)]
+}
@@ -23,9 +23,27 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/config/configtls"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/eks"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/cloudinit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/digitalocean"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/docker"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/envfile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/exec"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp/gce"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/hetzner"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/httpendpoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/podassociation"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/static"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -46,8 +64,20 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, p2, &Config{
 		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "gce")),
 		Detectors:         []string{"env", "gce"},
-		Timeout:           2 * time.Second,
-		Override:          false,
+		DetectorConfig: DetectorConfig{
+			GCEConfig: gce.Config{
+				Labels:       []string{"^team$"},
+				MetadataKeys: []string{"rack"},
+				HTTPClientSettings: internal.HTTPClientSettings{
+					ProxyURL: "http://proxy.internal:3128",
+					TLSSetting: configtls.TLSClientSetting{
+						TLSSetting: configtls.TLSSetting{CAFile: "/etc/ssl/proxy-ca.pem"},
+					},
+				},
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
 	})
 
 	p3 := cfg.Processors[config.NewIDWithName(typeStr, "ec2")]
@@ -56,12 +86,334 @@ func TestLoadConfig(t *testing.T) {
 		Detectors:         []string{"env", "ec2"},
 		DetectorConfig: DetectorConfig{
 			EC2Config: ec2.Config{
-				Tags: []string{"^tag1$", "^tag2$"},
+				Tags:                 []string{"^tag1$", "^tag2$"},
+				FailOnIMDSv1Fallback: true,
+				MaxRetries:           5,
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
+
+	p4 := cfg.Processors[config.NewIDWithName(typeStr, "concurrent")]
+	assert.Equal(t, p4, &Config{
+		ProcessorSettings:  config.NewProcessorSettings(config.NewIDWithName(typeStr, "concurrent")),
+		Detectors:          []string{"env", "ec2", "gce"},
+		Timeout:            2 * time.Second,
+		Override:           false,
+		DetectConcurrently: true,
+	})
+
+	p5 := cfg.Processors[config.NewIDWithName(typeStr, "refresh")]
+	assert.Equal(t, p5, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "refresh")),
+		Detectors:         []string{"env", "system"},
+		Timeout:           2 * time.Second,
+		Override:          false,
+		RefreshInterval:   5 * time.Minute,
+	})
+
+	pLazy := cfg.Processors[config.NewIDWithName(typeStr, "lazy")]
+	assert.Equal(t, pLazy, &Config{
+		ProcessorSettings:  config.NewProcessorSettings(config.NewIDWithName(typeStr, "lazy")),
+		Detectors:          []string{"env", "system"},
+		Timeout:            2 * time.Second,
+		Override:           false,
+		DetectOnFirstBatch: true,
+	})
+
+	pOnError := cfg.Processors[config.NewIDWithName(typeStr, "onerror")]
+	assert.Equal(t, pOnError, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "onerror")),
+		Detectors:         []string{"env", "system"},
+		Timeout:           2 * time.Second,
+		Override:          false,
+		OnError:           "retry_in_background",
+	})
+
+	pGateway := cfg.Processors[config.NewIDWithName(typeStr, "gateway")]
+	assert.Equal(t, pGateway, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "gateway")),
+		Detectors:         []string{"env", "system"},
+		Timeout:           2 * time.Second,
+		Override:          false,
+		PodAssociation: podassociation.Config{
+			APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
+			Enabled:   true,
+		},
+	})
+
+	pEntities := cfg.Processors[config.NewIDWithName(typeStr, "entities")]
+	assert.Equal(t, pEntities, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "entities")),
+		Detectors:         []string{"env", "system"},
+		Timeout:           2 * time.Second,
+		Override:          false,
+		EntityEvents: EntityEventsConfig{
+			Enabled:      true,
+			Interval:     time.Minute,
+			EntityType:   "host",
+			IDAttributes: []string{"host.id", "host.name"},
+		},
+	})
+
+	pTransforms := cfg.Processors[config.NewIDWithName(typeStr, "transforms")]
+	assert.Equal(t, pTransforms, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "transforms")),
+		Detectors:         []string{"env", "system"},
+		Timeout:           2 * time.Second,
+		Override:          false,
+		AttributeTransforms: []internal.AttributeTransform{
+			{Key: "host.name", Action: internal.TransformHash},
+			{Key: "host.id", Action: internal.TransformTruncate, Length: 8},
+		},
+		AttributeConversions: []internal.AttributeConversion{
+			{Key: "cloud.account.id", Convert: internal.ConvertToInt},
+		},
+	})
+
+	p6 := cfg.Processors[config.NewIDWithName(typeStr, "optional")]
+	assert.Equal(t, p6, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "optional")),
+		Detectors:         []string{"env", "ec2", "system"},
+		DetectorConfig: DetectorConfig{
+			DetectorSettings: map[string]internal.DetectorSettings{
+				"ec2": {
+					Optional:   true,
+					Attributes: internal.AttributesFilter{Include: []string{"cloud.region", "cloud.account.id"}},
+				},
+				"system": {
+					Attributes: internal.AttributesFilter{Exclude: []string{"os.type"}},
+				},
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
+
+	p7 := cfg.Processors[config.NewIDWithName(typeStr, "cache")]
+	assert.Equal(t, p7, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "cache")),
+		Detectors:         []string{"env", "ec2"},
+		Timeout:           2 * time.Second,
+		Override:          false,
+		Cache: CacheConfig{
+			Enabled: true,
+			MaxAge:  24 * time.Hour,
+			Storage: "file_storage/resourcedetection",
+		},
+	})
+
+	p8 := cfg.Processors[config.NewIDWithName(typeStr, "k8snode")]
+	assert.Equal(t, p8, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "k8snode")),
+		Detectors:         []string{"env", "k8snode"},
+		DetectorConfig: DetectorConfig{
+			K8sNodeConfig: k8snode.Config{
+				APIConfig:      k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
+				NodeFromEnvVar: "MY_NODE_NAME",
+				Labels:         []string{"topology.kubernetes.io/zone"},
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
+
+	p9 := cfg.Processors[config.NewIDWithName(typeStr, "openshift")]
+	assert.Equal(t, p9, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "openshift")),
+		Detectors:         []string{"env", "openshift"},
+		DetectorConfig: DetectorConfig{
+			OpenShiftConfig: openshift.Config{
+				Address:     "https://api.example.com:6443",
+				Token:       "some_token",
+				TLSSettings: configtls.TLSClientSetting{Insecure: true},
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
+
+	p10 := cfg.Processors[config.NewIDWithName(typeStr, "azure")]
+	assert.Equal(t, p10, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "azure")),
+		Detectors:         []string{"env", "azure"},
+		DetectorConfig: DetectorConfig{
+			AzureConfig: azure.Config{
+				Tags: []string{"^environment$"},
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
+
+	p11 := cfg.Processors[config.NewIDWithName(typeStr, "eks")]
+	assert.Equal(t, p11, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "eks")),
+		Detectors:         []string{"env", "eks"},
+		DetectorConfig: DetectorConfig{
+			EKSConfig: eks.Config{
+				ClusterNameStrategies: []string{"ec2_tags", "configmap"},
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
+
+	p12 := cfg.Processors[config.NewIDWithName(typeStr, "docker")]
+	assert.Equal(t, p12, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "docker")),
+		Detectors:         []string{"env", "docker"},
+		DetectorConfig: DetectorConfig{
+			DockerConfig: docker.Config{
+				Endpoint: "tcp://localhost:2375",
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
+
+	p13 := cfg.Processors[config.NewIDWithName(typeStr, "system")]
+	assert.Equal(t, p13, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "system")),
+		Detectors:         []string{"env", "system"},
+		DetectorConfig: DetectorConfig{
+			SystemConfig: system.Config{
+				HostIDSources: []string{"cloud", "machine-id"},
+				ResourceAttributes: system.ResourceAttributesConfig{
+					HostIP:    system.ResourceAttributeConfig{Enabled: true},
+					OSVersion: system.ResourceAttributeConfig{Enabled: true},
+				},
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
+
+	p14 := cfg.Processors[config.NewIDWithName(typeStr, "static")]
+	assert.Equal(t, p14, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "static")),
+		Detectors:         []string{"env", "static"},
+		DetectorConfig: DetectorConfig{
+			StaticConfig: static.Config{
+				Attributes: map[string]interface{}{
+					"deployment.environment": "production",
+					"service.replicas":       3,
+				},
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
+
+	p15 := cfg.Processors[config.NewIDWithName(typeStr, "envfile")]
+	assert.Equal(t, p15, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "envfile")),
+		Detectors:         []string{"env", "envfile"},
+		DetectorConfig: DetectorConfig{
+			EnvFileConfig: envfile.Config{
+				Path: "/etc/podinfo/resource.env",
+			},
+		},
+		Timeout:         2 * time.Second,
+		Override:        false,
+		RefreshInterval: time.Minute,
+	})
+
+	p16 := cfg.Processors[config.NewIDWithName(typeStr, "exec")]
+	assert.Equal(t, p16, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "exec")),
+		Detectors:         []string{"env", "exec"},
+		DetectorConfig: DetectorConfig{
+			ExecConfig: exec.Config{
+				Command: []string{"/usr/local/bin/host-metadata", "--format", "json"},
+				Timeout: 3 * time.Second,
+			},
+		},
+		Timeout:  5 * time.Second,
+		Override: false,
+	})
+
+	p17 := cfg.Processors[config.NewIDWithName(typeStr, "hetzner")]
+	assert.Equal(t, p17, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "hetzner")),
+		Detectors:         []string{"env", "hetzner"},
+		DetectorConfig: DetectorConfig{
+			HetznerConfig: hetzner.Config{
+				APIToken: "some_token",
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
+
+	p18 := cfg.Processors[config.NewIDWithName(typeStr, "digitalocean")]
+	assert.Equal(t, p18, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "digitalocean")),
+		Detectors:         []string{"env", "digitalocean"},
+		DetectorConfig: DetectorConfig{
+			DigitalOceanConfig: digitalocean.Config{
+				HTTPClientSettings: internal.HTTPClientSettings{
+					Retry: internal.RetrySettings{
+						MaxAttempts:     5,
+						InitialInterval: 500 * time.Millisecond,
+						Jitter:          0.2,
+					},
+				},
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
+
+	p19 := cfg.Processors[config.NewIDWithName(typeStr, "consul")]
+	assert.Equal(t, p19, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "consul")),
+		Detectors:         []string{"env", "consul"},
+		DetectorConfig: DetectorConfig{
+			ConsulConfig: consul.Config{
+				Address: "http://consul.service:8500",
+				Meta:    []string{"rack"},
 			},
 		},
 		Timeout:  2 * time.Second,
 		Override: false,
 	})
+
+	p20 := cfg.Processors[config.NewIDWithName(typeStr, "http")]
+	assert.Equal(t, p20, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "http")),
+		Detectors:         []string{"env", "http"},
+		DetectorConfig: DetectorConfig{
+			HTTPConfig: httpendpoint.Config{
+				Endpoint: "https://cmdb.internal/api/v1/hosts/self",
+				Headers:  map[string]string{"authorization": "Bearer token"},
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
+
+	p21 := cfg.Processors[config.NewIDWithName(typeStr, "cloudinit")]
+	assert.Equal(t, p21, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "cloudinit")),
+		Detectors:         []string{"env", "cloudinit"},
+		DetectorConfig: DetectorConfig{
+			CloudInitConfig: cloudinit.Config{Path: "/var/run/cloud-init/instance-data.json"},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
+}
+
+func TestGetSettingsFromType(t *testing.T) {
+	cfg := DetectorConfig{
+		DetectorSettings: map[string]internal.DetectorSettings{
+			"ec2": {Optional: true},
+		},
+	}
+	assert.Equal(t, internal.DetectorSettings{Optional: true}, cfg.GetSettingsFromType(ec2.TypeStr))
+	assert.Equal(t, internal.DetectorSettings{}, cfg.GetSettingsFromType("system"))
 }
 
 func TestGetConfigFromType(t *testing.T) {
@@ -102,3 +454,58 @@ func TestGetConfigFromType(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name:   "Default config",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:        "Invalid semconv version",
+			modify:      func(cfg *Config) { cfg.SemconvVersion = "0.1.0" },
+			expectedErr: `unsupported semantic conventions version "0.1.0"`,
+		},
+		{
+			name: "Invalid attribute conversion",
+			modify: func(cfg *Config) {
+				cfg.AttributeConversions = []internal.AttributeConversion{{Key: "cloud.account.id", Convert: "to_bytes"}}
+			},
+			expectedErr: `invalid conversion "to_bytes" for attribute "cloud.account.id", must be one of "to_int", "to_double", "to_string" or "to_bool"`,
+		},
+		{
+			name:        "Invalid on_error mode",
+			modify:      func(cfg *Config) { cfg.OnError = "panic" },
+			expectedErr: `invalid on_error mode "panic", must be one of "fail", "ignore" or "retry_in_background"`,
+		},
+		{
+			name: "Invalid attribute transform",
+			modify: func(cfg *Config) {
+				cfg.AttributeTransforms = []internal.AttributeTransform{{Key: "host.name", Action: "encrypt"}}
+			},
+			expectedErr: `invalid action "encrypt" for attribute "host.name", must be one of "hash", "truncate" or "redact"`,
+		},
+		{
+			name:        "Invalid cache storage",
+			modify:      func(cfg *Config) { cfg.Cache.Storage = "/file_storage" },
+			expectedErr: `invalid cache storage "/file_storage": idStr must have non empty type`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+// debugPath is the path the detected resource is served on.
+const debugPath = "/debug/resourcedetection"
+
+// debugResource is the JSON representation of the detected resource served by the debug endpoint.
+type debugResource struct {
+	Attributes map[string]interface{} `json:"attributes"`
+	// Sources maps each attribute to the detector that supplied it.
+	Sources map[string]string `json:"sources"`
+}
+
+// debugServer serves the resource detected by a provider over HTTP. Like the provider, it is
+// shared by the processors of all pipelines with the same processor configuration.
+type debugServer struct {
+	endpoint string
+	provider *internal.ResourceProvider
+	logger   *zap.Logger
+
+	server    *http.Server
+	startOnce sync.Once
+	startErr  error
+	stopOnce  sync.Once
+}
+
+func newDebugServer(endpoint string, provider *internal.ResourceProvider, logger *zap.Logger) *debugServer {
+	return &debugServer{endpoint: endpoint, provider: provider, logger: logger}
+}
+
+// start starts listening on the endpoint. Only the first call has an effect.
+func (s *debugServer) start() error {
+	s.startOnce.Do(func() {
+		listener, err := net.Listen("tcp", s.endpoint)
+		if err != nil {
+			s.startErr = fmt.Errorf("failed to listen on debug endpoint %q: %w", s.endpoint, err)
+			return
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle(debugPath, s)
+		s.server = &http.Server{Handler: mux}
+		go func() {
+			if err := s.server.Serve(listener); err != http.ErrServerClosed {
+				s.logger.Error("debug endpoint stopped serving", zap.Error(err))
+			}
+		}()
+	})
+	return s.startErr
+}
+
+// shutdown stops the server. Only the first call has an effect.
+func (s *debugServer) shutdown(ctx context.Context) error {
+	var err error
+	s.stopOnce.Do(func() {
+		if s.server != nil {
+			err = s.server.Shutdown(ctx)
+		}
+	})
+	return err
+}
+
+func (s *debugServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	res := debugResource{
+		Attributes: internal.AttributesToMap(s.provider.Resource().Attributes()),
+		Sources:    s.provider.AttributeSources(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		s.logger.Debug("failed writing debug response", zap.Error(err))
+	}
+}
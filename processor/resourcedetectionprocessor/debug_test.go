@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+func TestDebugServer(t *testing.T) {
+	md1 := &MockDetector{}
+	md1.On("Detect").Return(internal.NewResource(map[string]interface{}{"host.name": "node", "cloud.provider": "aws"}), nil)
+	md2 := &MockDetector{}
+	md2.On("Detect").Return(internal.NewResource(map[string]interface{}{"host.name": "ignored", "os.type": "linux"}), nil)
+
+	provider := internal.NewResourceProvider(zap.NewNop(), internal.ProviderSettings{Timeout: time.Second},
+		internal.ConfiguredDetector{Type: "ec2", Detector: md1},
+		internal.ConfiguredDetector{Type: "system", Detector: md2})
+	_, err := provider.Get(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	server := newDebugServer("localhost:0", provider, zap.NewNop())
+	require.NoError(t, server.start())
+	defer func() { assert.NoError(t, server.shutdown(context.Background())) }()
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, debugPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var got debugResource
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, debugResource{
+		Attributes: map[string]interface{}{"host.name": "node", "cloud.provider": "aws", "os.type": "linux"},
+		Sources:    map[string]string{"host.name": "ec2", "cloud.provider": "ec2", "os.type": "system"},
+	}, got)
+}
+
+func TestDebugServerInvalidEndpoint(t *testing.T) {
+	server := newDebugServer("invalid:endpoint:1", nil, zap.NewNop())
+	assert.Error(t, server.start())
+	assert.NoError(t, server.shutdown(context.Background()))
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+// Attributes of entity state event log records.
+const (
+	attributeEntityEventType  = "otel.entity.event.type"
+	attributeEntityType       = "otel.entity.type"
+	attributeEntityID         = "otel.entity.id"
+	attributeEntityAttributes = "otel.entity.attributes"
+	attributeEntityInterval   = "otel.entity.interval"
+
+	entityEventTypeState = "entity_state"
+)
+
+const (
+	defaultEntityEventsInterval = 5 * time.Minute
+	defaultEntityType           = "host"
+)
+
+var defaultEntityIDAttributes = []string{"host.id"}
+
+// EntityEventsConfig configures emitting the detected resource as entity state events.
+type EntityEventsConfig struct {
+	// Enabled indicates whether entity state events should be emitted. They are only
+	// emitted by the processor of a logs pipeline. Defaults to false.
+	Enabled bool `mapstructure:"enabled"`
+	// Interval specifies how often an entity state event is emitted. Defaults to 5m.
+	Interval time.Duration `mapstructure:"interval"`
+	// EntityType is the type of the entity described by the detected resource. Defaults to "host".
+	EntityType string `mapstructure:"entity_type"`
+	// IDAttributes are the detected attributes that identify the entity, the remaining ones are
+	// descriptive. No event is emitted unless all of them were detected. Defaults to ["host.id"].
+	IDAttributes []string `mapstructure:"id_attributes"`
+}
+
+// entityEmitter periodically sends the resource detected by a provider to the next consumer
+// of a logs pipeline as an entity state event.
+type entityEmitter struct {
+	provider     *internal.ResourceProvider
+	next         consumer.Logs
+	logger       *zap.Logger
+	interval     time.Duration
+	entityType   string
+	idAttributes []string
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func newEntityEmitter(cfg EntityEventsConfig, provider *internal.ResourceProvider, next consumer.Logs, logger *zap.Logger) *entityEmitter {
+	e := &entityEmitter{
+		provider:     provider,
+		next:         next,
+		logger:       logger,
+		interval:     cfg.Interval,
+		entityType:   cfg.EntityType,
+		idAttributes: cfg.IDAttributes,
+		stopCh:       make(chan struct{}),
+	}
+	if e.interval <= 0 {
+		e.interval = defaultEntityEventsInterval
+	}
+	if e.entityType == "" {
+		e.entityType = defaultEntityType
+	}
+	if len(e.idAttributes) == 0 {
+		e.idAttributes = defaultEntityIDAttributes
+	}
+	return e
+}
+
+// start emits an event right away and then on every interval, until shutdown is called.
+func (e *entityEmitter) start() {
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+
+		e.emit()
+		for {
+			select {
+			case <-ticker.C:
+				e.emit()
+			case <-e.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// shutdown stops emitting events and waits for an event being sent.
+func (e *entityEmitter) shutdown() {
+	e.stopOnce.Do(func() {
+		close(e.stopCh)
+	})
+	e.wg.Wait()
+}
+
+func (e *entityEmitter) emit() {
+	ld, ok := e.entityEvent(e.provider.Resource(), time.Now())
+	if !ok {
+		return
+	}
+	if err := e.next.ConsumeLogs(context.Background(), ld); err != nil {
+		e.logger.Warn("failed sending entity state event", zap.Error(err))
+	}
+}
+
+// entityEvent builds an entity state event for res, or returns false if res lacks an ID attribute,
+// e.g. because detection has not succeeded yet.
+func (e *entityEmitter) entityEvent(res pdata.Resource, now time.Time) (pdata.Logs, bool) {
+	id := pdata.NewAttributeValueMap()
+	descriptive := pdata.NewAttributeValueMap()
+	res.Attributes().CopyTo(descriptive.MapVal())
+	for _, key := range e.idAttributes {
+		v, ok := descriptive.MapVal().Get(key)
+		if !ok {
+			e.logger.Debug("not emitting entity state event, id attribute was not detected", zap.String("attribute", key))
+			return pdata.Logs{}, false
+		}
+		id.MapVal().Insert(key, v)
+		descriptive.MapVal().Delete(key)
+	}
+
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetTimestamp(pdata.TimestampFromTime(now))
+
+	attr := lr.Attributes()
+	attr.InsertString(attributeEntityEventType, entityEventTypeState)
+	attr.InsertString(attributeEntityType, e.entityType)
+	attr.Insert(attributeEntityID, id)
+	attr.Insert(attributeEntityAttributes, descriptive)
+	attr.InsertInt(attributeEntityInterval, e.interval.Milliseconds())
+	return ld, true
+}
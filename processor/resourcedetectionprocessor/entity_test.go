@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+func TestEntityEvent(t *testing.T) {
+	e := newEntityEmitter(EntityEventsConfig{IDAttributes: []string{"cloud.provider", "host.id"}}, nil, nil, zap.NewNop())
+	now := time.Unix(1600000000, 0)
+
+	ld, ok := e.entityEvent(internal.NewResource(map[string]interface{}{
+		"cloud.provider": "aws",
+		"host.id":        "i-abcd1234",
+		"host.name":      "node",
+	}), now)
+	require.True(t, ok)
+	require.Equal(t, 1, ld.LogRecordCount())
+
+	lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.TimestampFromTime(now), lr.Timestamp())
+	assert.Equal(t, map[string]interface{}{
+		"otel.entity.event.type": "entity_state",
+		"otel.entity.type":       "host",
+		"otel.entity.id":         map[string]interface{}{"cloud.provider": "aws", "host.id": "i-abcd1234"},
+		"otel.entity.attributes": map[string]interface{}{"host.name": "node"},
+		"otel.entity.interval":   int64(300000),
+	}, internal.AttributesToMap(lr.Attributes()))
+}
+
+func TestEntityEventMissingID(t *testing.T) {
+	e := newEntityEmitter(EntityEventsConfig{}, nil, nil, zap.NewNop())
+
+	_, ok := e.entityEvent(internal.NewResource(map[string]interface{}{"host.name": "node"}), time.Now())
+	assert.False(t, ok)
+
+	_, ok = e.entityEvent(pdata.NewResource(), time.Now())
+	assert.False(t, ok)
+}
+
+func TestEntityEmitter(t *testing.T) {
+	md := &MockDetector{}
+	md.On("Detect").Return(internal.NewResource(map[string]interface{}{"host.id": "i-abcd1234", "host.name": "node"}), nil)
+
+	provider := internal.NewResourceProvider(zap.NewNop(), internal.ProviderSettings{Timeout: time.Second},
+		internal.ConfiguredDetector{Type: "mock", Detector: md})
+	_, err := provider.Get(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	sink := new(consumertest.LogsSink)
+	e := newEntityEmitter(EntityEventsConfig{Interval: 10 * time.Millisecond, EntityType: "node"}, provider, sink, zap.NewNop())
+	e.start()
+	assert.Eventually(t, func() bool {
+		return sink.LogRecordsCount() >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+	e.shutdown()
+
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	entityType, ok := lr.Attributes().Get("otel.entity.type")
+	require.True(t, ok)
+	assert.Equal(t, "node", entityType.StringVal())
+}
@@ -16,10 +16,12 @@ package resourcedetectionprocessor
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"sync"
 	"time"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
@@ -32,10 +34,30 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/elasticbeanstalk"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure/aks"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure/appservice"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/cloudfoundry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/cloudinit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/digitalocean"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/docker"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/env"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/envfile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/exec"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp/cloudfunctions"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp/cloudrun"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp/gce"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp/gke"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/hetzner"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/httpendpoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/ibmcloud"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/oci"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/podassociation"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/scaleway"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/static"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/tencentcloud"
 )
 
 const (
@@ -51,27 +73,55 @@ type factory struct {
 	// providers stores a provider for each named processor that
 	// may a different set of detectors configured.
 	providers map[config.ComponentID]*internal.ResourceProvider
-	lock      sync.Mutex
+	// debugServers stores the debug server of each named processor with a debug endpoint.
+	debugServers map[config.ComponentID]*debugServer
+	// podResolvers stores the pod resolver of each named processor with pod association enabled.
+	podResolvers map[config.ComponentID]*podassociation.Resolver
+	lock         sync.Mutex
 }
 
 // NewFactory creates a new factory for ResourceDetection processor.
 func NewFactory() component.ProcessorFactory {
+	// the views can only be registered once, the error on subsequent registrations is ignored
+	_ = view.Register(MetricViews()...)
+
 	resourceProviderFactory := internal.NewProviderFactory(map[internal.DetectorType]internal.DetectorFactory{
 		aks.TypeStr:              aks.NewDetector,
+		appservice.TypeStr:       appservice.NewDetector,
 		azure.TypeStr:            azure.NewDetector,
+		cloudfoundry.TypeStr:     cloudfoundry.NewDetector,
+		cloudfunctions.TypeStr:   cloudfunctions.NewDetector,
+		cloudinit.TypeStr:        cloudinit.NewDetector,
+		cloudrun.TypeStr:         cloudrun.NewDetector,
+		consul.TypeStr:           consul.NewDetector,
+		digitalocean.TypeStr:     digitalocean.NewDetector,
+		docker.TypeStr:           docker.NewDetector,
 		ec2.TypeStr:              ec2.NewDetector,
 		ecs.TypeStr:              ecs.NewDetector,
 		eks.TypeStr:              eks.NewDetector,
 		elasticbeanstalk.TypeStr: elasticbeanstalk.NewDetector,
 		env.TypeStr:              env.NewDetector,
+		envfile.TypeStr:          envfile.NewDetector,
+		exec.TypeStr:             exec.NewDetector,
 		gce.TypeStr:              gce.NewDetector,
 		gke.TypeStr:              gke.NewDetector,
+		hetzner.TypeStr:          hetzner.NewDetector,
+		httpendpoint.TypeStr:     httpendpoint.NewDetector,
+		ibmcloud.TypeStr:         ibmcloud.NewDetector,
+		k8snode.TypeStr:          k8snode.NewDetector,
+		oci.TypeStr:              oci.NewDetector,
+		openshift.TypeStr:        openshift.NewDetector,
+		scaleway.TypeStr:         scaleway.NewDetector,
+		static.TypeStr:           static.NewDetector,
 		system.TypeStr:           system.NewDetector,
+		tencentcloud.TypeStr:     tencentcloud.NewDetector,
 	})
 
 	f := &factory{
 		resourceProviderFactory: resourceProviderFactory,
 		providers:               map[config.ComponentID]*internal.ResourceProvider{},
+		debugServers:            map[config.ComponentID]*debugServer{},
+		podResolvers:            map[config.ComponentID]*podassociation.Resolver{},
 	}
 
 	return processorhelper.NewFactory(
@@ -112,7 +162,8 @@ func (f *factory) createTracesProcessor(
 		nextConsumer,
 		rdp,
 		processorhelper.WithCapabilities(consumerCapabilities),
-		processorhelper.WithStart(rdp.Start))
+		processorhelper.WithStart(rdp.Start),
+		processorhelper.WithShutdown(rdp.Shutdown))
 }
 
 func (f *factory) createMetricsProcessor(
@@ -131,7 +182,8 @@ func (f *factory) createMetricsProcessor(
 		nextConsumer,
 		rdp,
 		processorhelper.WithCapabilities(consumerCapabilities),
-		processorhelper.WithStart(rdp.Start))
+		processorhelper.WithStart(rdp.Start),
+		processorhelper.WithShutdown(rdp.Shutdown))
 }
 
 func (f *factory) createLogsProcessor(
@@ -145,12 +197,17 @@ func (f *factory) createLogsProcessor(
 		return nil, err
 	}
 
+	if eCfg := cfg.(*Config).EntityEvents; eCfg.Enabled {
+		rdp.entityEmitter = newEntityEmitter(eCfg, rdp.provider, nextConsumer, params.Logger)
+	}
+
 	return processorhelper.NewLogsProcessor(
 		cfg,
 		nextConsumer,
 		rdp,
 		processorhelper.WithCapabilities(consumerCapabilities),
-		processorhelper.WithStart(rdp.Start))
+		processorhelper.WithStart(rdp.Start),
+		processorhelper.WithShutdown(rdp.Shutdown))
 }
 
 func (f *factory) getResourceDetectionProcessor(
@@ -159,37 +216,87 @@ func (f *factory) getResourceDetectionProcessor(
 ) (*resourceDetectionProcessor, error) {
 	oCfg := cfg.(*Config)
 
-	provider, err := f.getResourceProvider(params, cfg.ID(), oCfg.Timeout, oCfg.Detectors, oCfg.DetectorConfig)
+	onError := oCfg.OnError
+	if onError == "" {
+		onError = onErrorFail
+	}
+
+	provider, err := f.getResourceProvider(params, oCfg)
 	if err != nil {
 		return nil, err
 	}
 
+	overrideAttributes := make(map[string]struct{}, len(oCfg.OverrideAttributes))
+	for _, key := range oCfg.OverrideAttributes {
+		overrideAttributes[key] = struct{}{}
+	}
+
+	var debug *debugServer
+	if oCfg.DebugEndpoint != "" {
+		debug = f.getDebugServer(params, cfg.ID(), oCfg.DebugEndpoint, provider)
+	}
+
+	var resolver podResolver
+	if oCfg.PodAssociation.Enabled {
+		if resolver, err = f.getPodResolver(cfg.ID(), oCfg.PodAssociation); err != nil {
+			return nil, err
+		}
+	}
+
 	return &resourceDetectionProcessor{
-		provider: provider,
-		override: oCfg.Override,
+		logger:             params.Logger,
+		provider:           provider,
+		debug:              debug,
+		override:           oCfg.Override,
+		overrideAttributes: overrideAttributes,
+		detectOnFirstBatch: oCfg.DetectOnFirstBatch,
+		onError:            onError,
+		podResolver:        resolver,
 	}, nil
 }
 
 func (f *factory) getResourceProvider(
 	params component.ProcessorCreateParams,
-	processorName config.ComponentID,
-	timeout time.Duration,
-	configuredDetectors []string,
-	detectorConfigs DetectorConfig,
+	cfg *Config,
 ) (*internal.ResourceProvider, error) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
+	processorName := cfg.ID()
 	if provider, ok := f.providers[processorName]; ok {
 		return provider, nil
 	}
 
-	detectorTypes := make([]internal.DetectorType, 0, len(configuredDetectors))
-	for _, key := range configuredDetectors {
+	detectorTypes := make([]internal.DetectorType, 0, len(cfg.Detectors))
+	for _, key := range cfg.Detectors {
 		detectorTypes = append(detectorTypes, internal.DetectorType(strings.TrimSpace(key)))
 	}
 
-	provider, err := f.resourceProviderFactory.CreateResourceProvider(params, timeout, &detectorConfigs, detectorTypes...)
+	// an unsupported version is already rejected by Config.Validate
+	attributeRenames, err := internal.AttributeRenames(cfg.SemconvVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var cache *internal.ResourceCache
+	if cfg.Cache.Enabled {
+		storageID, err := cfg.Cache.storageID()
+		if err != nil {
+			return nil, err
+		}
+		cache = internal.NewResourceCache(processorName, storageID, cfg.Cache.MaxAge)
+	}
+
+	settings := internal.ProviderSettings{
+		Timeout:              cfg.Timeout,
+		DetectConcurrently:   cfg.DetectConcurrently,
+		RefreshInterval:      cfg.RefreshInterval,
+		Cache:                cache,
+		AttributeRenames:     attributeRenames,
+		AttributeTransforms:  cfg.AttributeTransforms,
+		AttributeConversions: cfg.AttributeConversions,
+	}
+	provider, err := f.resourceProviderFactory.CreateResourceProvider(params, settings, &cfg.DetectorConfig, detectorTypes...)
 	if err != nil {
 		return nil, err
 	}
@@ -197,3 +304,37 @@ func (f *factory) getResourceProvider(
 	f.providers[processorName] = provider
 	return provider, nil
 }
+
+func (f *factory) getDebugServer(
+	params component.ProcessorCreateParams,
+	processorName config.ComponentID,
+	endpoint string,
+	provider *internal.ResourceProvider,
+) *debugServer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if server, ok := f.debugServers[processorName]; ok {
+		return server
+	}
+
+	server := newDebugServer(endpoint, provider, params.Logger)
+	f.debugServers[processorName] = server
+	return server
+}
+
+func (f *factory) getPodResolver(processorName config.ComponentID, cfg podassociation.Config) (*podassociation.Resolver, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if resolver, ok := f.podResolvers[processorName]; ok {
+		return resolver, nil
+	}
+
+	resolver, err := podassociation.NewResolver(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating pod resolver: %w", err)
+	}
+	f.podResolvers[processorName] = resolver
+	return resolver, nil
+}
@@ -14,9 +14,49 @@
 
 package ec2
 
+import (
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
 // Config defines user-specified configurations unique to the EC2 detector
 type Config struct {
 	// Tags is a list of regex's to match ec2 instance tag keys that users want
 	// to add as resource attributes to processed data
 	Tags []string `mapstructure:"tags"`
+
+	// FailOnIMDSv1Fallback makes requests to the instance metadata service fail
+	// instead of falling back to IMDSv1 when no IMDSv2 session token can be acquired,
+	// e.g. because the response hop limit is too low for the collector's container.
+	FailOnIMDSv1Fallback bool `mapstructure:"fail_on_imdsv1_fallback"`
+
+	// CapacityReservation enables reading the ID of the capacity reservation the instance
+	// runs in. It is not available in the instance metadata, so it is read from the EC2 API,
+	// which requires the ec2:DescribeInstances permission.
+	CapacityReservation bool `mapstructure:"capacity_reservation"`
+
+	// MaxRetries is the maximum number of times a failed request to the instance
+	// metadata service is retried, with exponential backoff. Defaults to the AWS SDK default.
+	//
+	// Deprecated: use Retry.MaxAttempts, which takes precedence if both are set.
+	MaxRetries int `mapstructure:"max_retries"`
+
+	// HTTPClientSettings configures the proxy, TLS and retry settings of the requests to the
+	// instance metadata service and the EC2 API. The requests are retried by the AWS SDK rather
+	// than by the HTTP client, so only Retry.MaxAttempts is used, with the backoff of the SDK.
+	internal.HTTPClientSettings `mapstructure:",squash"`
+}
+
+// withRetries sets the number of times the AWS SDK retries a failed request, from Retry.MaxAttempts
+// or else from the deprecated MaxRetries. If neither is set, the SDK default is kept.
+func (cfg Config) withRetries(awsCfg *aws.Config) *aws.Config {
+	switch {
+	case cfg.Retry.MaxAttempts > 0:
+		return awsCfg.WithMaxRetries(cfg.Retry.MaxAttempts - 1)
+	case cfg.MaxRetries > 0:
+		return awsCfg.WithMaxRetries(cfg.MaxRetries)
+	default:
+		return awsCfg
+	}
 }
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 )
@@ -33,6 +34,12 @@ import (
 const (
 	TypeStr   = "ec2"
 	tagPrefix = "ec2.tag."
+
+	// AZ names are mapped to physical zones per account, AZ IDs are the same for all accounts
+	attributeCloudAvailabilityZoneID = "cloud.availability_zone.id"
+
+	attributeLifecycle             = "aws.ec2.lifecycle"
+	attributeCapacityReservationID = "aws.ec2.capacity_reservation.id"
 )
 
 var _ internal.Detector = (*Detector)(nil)
@@ -40,11 +47,31 @@ var _ internal.Detector = (*Detector)(nil)
 type Detector struct {
 	metadataProvider metadataProvider
 	tagKeyRegexes    []*regexp.Regexp
+	logger           *zap.Logger
+	// capacityReservation enables reading the capacity reservation ID from the EC2 API
+	capacityReservation bool
+	// awsConfig holds the HTTP client and retry settings of the EC2 API requests
+	awsConfig *aws.Config
 }
 
-func NewDetector(_ component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+func NewDetector(params component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
 	cfg := dcfg.(Config)
-	sess, err := session.NewSession()
+	if cfg.MaxRetries > 0 {
+		params.Logger.Warn("The max_retries setting of the ec2 detector is deprecated, use retry::max_attempts instead")
+	}
+
+	// the AWS SDK retries failed requests itself, retrying them in the HTTP client as well would multiply the attempts
+	clientSettings := cfg.HTTPClientSettings
+	clientSettings.Retry = internal.RetrySettings{}
+	client, err := clientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	awsCfg := cfg.withRetries(aws.NewConfig())
+	if client != nil {
+		awsCfg = awsCfg.WithHTTPClient(client)
+	}
+	sess, err := session.NewSession(awsCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -52,12 +79,22 @@ func NewDetector(_ component.ProcessorCreateParams, dcfg internal.DetectorConfig
 	if err != nil {
 		return nil, err
 	}
-	return &Detector{metadataProvider: newMetadataClient(sess), tagKeyRegexes: tagKeyRegexes}, nil
+	return &Detector{
+		metadataProvider:    newMetadataClient(sess, cfg),
+		tagKeyRegexes:       tagKeyRegexes,
+		logger:              params.Logger,
+		capacityReservation: cfg.CapacityReservation,
+		awsConfig:           awsCfg,
+	}, nil
 }
 
 func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
 	res := pdata.NewResource()
-	if !d.metadataProvider.available(ctx) {
+	available, err := d.metadataProvider.available(ctx)
+	if err != nil {
+		return res, fmt.Errorf("failed checking the instance metadata service: %w", err)
+	}
+	if !available {
 		return res, nil
 	}
 
@@ -82,10 +119,37 @@ func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
 	attr.InsertString(conventions.AttributeHostType, meta.InstanceType)
 	attr.InsertString(conventions.AttributeHostName, hostname)
 
+	if azID, err := d.metadataProvider.availabilityZoneID(ctx); err != nil {
+		d.logger.Debug("Failed getting availability zone ID", zap.Error(err))
+	} else {
+		attr.InsertString(attributeCloudAvailabilityZoneID, azID)
+	}
+
+	if lifecycle, err := d.metadataProvider.lifecycle(ctx); err != nil {
+		d.logger.Debug("Failed getting instance lifecycle", zap.Error(err))
+	} else {
+		attr.InsertString(attributeLifecycle, lifecycle)
+	}
+
+	if d.capacityReservation {
+		reservationID, err := connectAndFetchCapacityReservationID(meta.Region, meta.InstanceID, d.awsConfig)
+		if err != nil {
+			return res, fmt.Errorf("failed fetching ec2 capacity reservation: %w", err)
+		}
+		if reservationID != "" {
+			attr.InsertString(attributeCapacityReservationID, reservationID)
+		}
+	}
+
 	if len(d.tagKeyRegexes) != 0 {
-		tags, err := connectAndFetchEc2Tags(meta.Region, meta.InstanceID, d.tagKeyRegexes)
+		// Reading the tags from the instance metadata does not need any IAM permissions,
+		// but has to be enabled on the instance, so fall back to the EC2 API if it fails.
+		tags, err := fetchIMDSTags(ctx, d.metadataProvider, d.tagKeyRegexes)
 		if err != nil {
-			return res, fmt.Errorf("failed fetching ec2 instance tags: %w", err)
+			tags, err = connectAndFetchEc2Tags(meta.Region, meta.InstanceID, d.tagKeyRegexes, d.awsConfig)
+			if err != nil {
+				return res, fmt.Errorf("failed fetching ec2 instance tags: %w", err)
+			}
 		}
 		for key, val := range tags {
 			attr.InsertString(tagPrefix+key, val)
@@ -95,18 +159,70 @@ func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
 	return res, nil
 }
 
-func connectAndFetchEc2Tags(region string, instanceID string, tagKeyRegexes []*regexp.Regexp) (map[string]string, error) {
-	sess, err := session.NewSession(&aws.Config{
-		Region: aws.String(region)},
-	)
+func fetchIMDSTags(ctx context.Context, provider metadataProvider, tagKeyRegexes []*regexp.Regexp) (map[string]string, error) {
+	keys, err := provider.tagKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string)
+	for _, key := range keys {
+		if !regexArrayMatch(tagKeyRegexes, key) {
+			continue
+		}
+		val, err := provider.tagValue(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		tags[key] = val
+	}
+	return tags, nil
+}
+
+func newEC2Client(region string, awsCfg *aws.Config) (*ec2.EC2, error) {
+	sess, err := session.NewSession(awsCfg.Copy().WithRegion(region))
+	if err != nil {
+		return nil, err
+	}
+	return ec2.New(sess), nil
+}
+
+func connectAndFetchEc2Tags(region string, instanceID string, tagKeyRegexes []*regexp.Regexp, awsCfg *aws.Config) (map[string]string, error) {
+	e, err := newEC2Client(region, awsCfg)
 	if err != nil {
 		return nil, err
 	}
-	e := ec2.New(sess)
 
 	return fetchEC2Tags(e, instanceID, tagKeyRegexes)
 }
 
+func connectAndFetchCapacityReservationID(region string, instanceID string, awsCfg *aws.Config) (string, error) {
+	e, err := newEC2Client(region, awsCfg)
+	if err != nil {
+		return "", err
+	}
+
+	return fetchCapacityReservationID(e, instanceID)
+}
+
+// fetchCapacityReservationID returns the ID of the capacity reservation the instance runs in,
+// or an empty string if it does not run in one.
+func fetchCapacityReservationID(svc ec2iface.EC2API, instanceID string) (string, error) {
+	out, err := svc.DescribeInstances(&ec2.DescribeInstancesInput{
+		InstanceIds: []*string{aws.String(instanceID)},
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, reservation := range out.Reservations {
+		for _, instance := range reservation.Instances {
+			if aws.StringValue(instance.InstanceId) == instanceID {
+				return aws.StringValue(instance.CapacityReservationId), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("instance %q not found", instanceID)
+}
+
 func fetchEC2Tags(svc ec2iface.EC2API, instanceID string, tagKeyRegexes []*regexp.Regexp) (map[string]string, error) {
 	ec2Tags, err := svc.DescribeTags(&ec2.DescribeTagsInput{
 		Filters: []*ec2.Filter{{
@@ -17,9 +17,14 @@ package ec2
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"regexp"
+	"sync"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
@@ -39,13 +44,23 @@ type mockMetadata struct {
 	retHostname    string
 	retErrHostname error
 
-	isAvailable bool
+	retAZID    string
+	retErrAZID error
+
+	retLifecycle    string
+	retErrLifecycle error
+
+	retTags    map[string]string
+	retErrTags error
+
+	isAvailable     bool
+	retErrAvailable error
 }
 
 var _ metadataProvider = (*mockMetadata)(nil)
 
-func (mm mockMetadata) available(ctx context.Context) bool {
-	return mm.isAvailable
+func (mm mockMetadata) available(ctx context.Context) (bool, error) {
+	return mm.isAvailable, mm.retErrAvailable
 }
 
 func (mm mockMetadata) get(ctx context.Context) (ec2metadata.EC2InstanceIdentityDocument, error) {
@@ -62,6 +77,38 @@ func (mm mockMetadata) hostname(ctx context.Context) (string, error) {
 	return mm.retHostname, nil
 }
 
+func (mm mockMetadata) availabilityZoneID(ctx context.Context) (string, error) {
+	if mm.retErrAZID != nil {
+		return "", mm.retErrAZID
+	}
+	return mm.retAZID, nil
+}
+
+func (mm mockMetadata) lifecycle(ctx context.Context) (string, error) {
+	if mm.retErrLifecycle != nil {
+		return "", mm.retErrLifecycle
+	}
+	return mm.retLifecycle, nil
+}
+
+func (mm mockMetadata) tagKeys(ctx context.Context) ([]string, error) {
+	if mm.retErrTags != nil {
+		return nil, mm.retErrTags
+	}
+	keys := make([]string, 0, len(mm.retTags))
+	for key := range mm.retTags {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (mm mockMetadata) tagValue(ctx context.Context, key string) (string, error) {
+	if mm.retErrTags != nil {
+		return "", mm.retErrTags
+	}
+	return mm.retTags[key], nil
+}
+
 func TestNewDetector(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -102,9 +149,67 @@ func TestNewDetector(t *testing.T) {
 	}
 }
 
+func TestNewDetectorRetries(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            Config
+		wantMaxRetries *int
+	}{
+		{
+			name: "SDK default",
+			cfg:  Config{},
+		},
+		{
+			name:           "max_retries",
+			cfg:            Config{MaxRetries: 5},
+			wantMaxRetries: aws.Int(5),
+		},
+		{
+			name: "retry takes precedence",
+			cfg: Config{
+				MaxRetries:         5,
+				HTTPClientSettings: internal.HTTPClientSettings{Retry: internal.RetrySettings{MaxAttempts: 3}},
+			},
+			wantMaxRetries: aws.Int(2),
+		},
+		{
+			name:           "no retries",
+			cfg:            Config{HTTPClientSettings: internal.HTTPClientSettings{Retry: internal.RetrySettings{MaxAttempts: 1}}},
+			wantMaxRetries: aws.Int(0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, tt.cfg)
+			require.NoError(t, err)
+
+			awsCfg := detector.(*Detector).awsConfig
+			assert.Equal(t, tt.wantMaxRetries, awsCfg.MaxRetries)
+			// the requests are only retried by the AWS SDK
+			assert.Nil(t, awsCfg.HTTPClient)
+		})
+	}
+}
+
+func TestNewDetectorRetriesWithProxy(t *testing.T) {
+	detector, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{
+		HTTPClientSettings: internal.HTTPClientSettings{
+			ProxyURL: "http://proxy.internal:3128",
+			Retry:    internal.RetrySettings{MaxAttempts: 3},
+		},
+	})
+	require.NoError(t, err)
+
+	awsCfg := detector.(*Detector).awsConfig
+	assert.Equal(t, aws.Int(2), awsCfg.MaxRetries)
+	require.NotNil(t, awsCfg.HTTPClient)
+	assert.IsType(t, &http.Transport{}, awsCfg.HTTPClient.Transport)
+}
+
 func TestDetector_Detect(t *testing.T) {
 	type fields struct {
 		metadataProvider metadataProvider
+		tagKeyRegexes    []*regexp.Regexp
 	}
 	type args struct {
 		ctx context.Context
@@ -127,8 +232,10 @@ func TestDetector_Detect(t *testing.T) {
 					ImageID:          "abcdef",
 					InstanceType:     "c4.xlarge",
 				},
-				retHostname: "example-hostname",
-				isAvailable: true}},
+				retHostname:  "example-hostname",
+				retAZID:      "usw2-az1",
+				retLifecycle: "spot",
+				isAvailable:  true}},
 			args: args{ctx: context.Background()},
 			want: func() pdata.Resource {
 				res := pdata.NewResource()
@@ -138,12 +245,46 @@ func TestDetector_Detect(t *testing.T) {
 				attr.InsertString("cloud.platform", "aws_ec2")
 				attr.InsertString("cloud.region", "us-west-2")
 				attr.InsertString("cloud.availability_zone", "us-west-2a")
+				attr.InsertString("cloud.availability_zone.id", "usw2-az1")
+				attr.InsertString("aws.ec2.lifecycle", "spot")
 				attr.InsertString("host.id", "i-abcd1234")
 				attr.InsertString("host.image.id", "abcdef")
 				attr.InsertString("host.type", "c4.xlarge")
 				attr.InsertString("host.name", "example-hostname")
 				return res
 			}()},
+		{
+			name: "success with tags from instance metadata",
+			fields: fields{
+				metadataProvider: &mockMetadata{
+					retIDDoc: ec2metadata.EC2InstanceIdentityDocument{
+						Region:     "us-west-2",
+						InstanceID: "i-abcd1234",
+					},
+					retHostname:     "example-hostname",
+					retErrAZID:      errors.New("not found"),
+					retErrLifecycle: errors.New("not found"),
+					retTags:         map[string]string{"tag1": "val1", "tag2": "val2", "other": "val3"},
+					isAvailable:     true},
+				tagKeyRegexes: []*regexp.Regexp{regexp.MustCompile("^tag")},
+			},
+			args: args{ctx: context.Background()},
+			want: func() pdata.Resource {
+				res := pdata.NewResource()
+				attr := res.Attributes()
+				attr.InsertString("cloud.account.id", "")
+				attr.InsertString("cloud.provider", "aws")
+				attr.InsertString("cloud.platform", "aws_ec2")
+				attr.InsertString("cloud.region", "us-west-2")
+				attr.InsertString("cloud.availability_zone", "")
+				attr.InsertString("host.id", "i-abcd1234")
+				attr.InsertString("host.image.id", "")
+				attr.InsertString("host.type", "")
+				attr.InsertString("host.name", "example-hostname")
+				attr.InsertString("ec2.tag.tag1", "val1")
+				attr.InsertString("ec2.tag.tag2", "val2")
+				return res
+			}()},
 		{
 			name: "endpoint not available",
 			fields: fields{metadataProvider: &mockMetadata{
@@ -156,6 +297,17 @@ func TestDetector_Detect(t *testing.T) {
 				return pdata.NewResource()
 			}(),
 			wantErr: false},
+		{
+			name: "IMDSv1 fallback disabled",
+			fields: fields{metadataProvider: &mockMetadata{
+				retErrIDDoc:     errors.New("should not be called"),
+				retErrAvailable: errIMDSv1Fallback,
+			}},
+			args: args{ctx: context.Background()},
+			want: func() pdata.Resource {
+				return pdata.NewResource()
+			}(),
+			wantErr: true},
 		{
 			name: "get fails",
 			fields: fields{metadataProvider: &mockMetadata{
@@ -186,6 +338,8 @@ func TestDetector_Detect(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			d := &Detector{
 				metadataProvider: tt.fields.metadataProvider,
+				tagKeyRegexes:    tt.fields.tagKeyRegexes,
+				logger:           zap.NewNop(),
 			}
 			got, err := d.Detect(tt.args.ctx)
 
@@ -226,6 +380,46 @@ func (m *mockEC2Client) DescribeTags(input *ec2.DescribeTagsInput) (*ec2.Describ
 	}, nil
 }
 
+// override the DescribeInstances function to mock the output from an actual EC2 instance
+func (m *mockEC2Client) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	switch *input.InstanceIds[0] {
+	case "error":
+		return nil, errors.New("error")
+	case "missing":
+		return &ec2.DescribeInstancesOutput{}, nil
+	}
+
+	reserved := "i-reserved"
+	reservationID := "cr-0123456789abcdef"
+	onDemand := "i-ondemand"
+	return &ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{{
+			Instances: []*ec2.Instance{
+				{InstanceId: &reserved, CapacityReservationId: &reservationID},
+				{InstanceId: &onDemand},
+			},
+		}},
+	}, nil
+}
+
+func TestFetchCapacityReservationID(t *testing.T) {
+	m := &mockEC2Client{}
+
+	id, err := fetchCapacityReservationID(m, "i-reserved")
+	require.NoError(t, err)
+	assert.Equal(t, "cr-0123456789abcdef", id)
+
+	id, err = fetchCapacityReservationID(m, "i-ondemand")
+	require.NoError(t, err)
+	assert.Equal(t, "", id)
+
+	_, err = fetchCapacityReservationID(m, "missing")
+	assert.EqualError(t, err, `instance "missing" not found`)
+
+	_, err = fetchCapacityReservationID(m, "error")
+	assert.EqualError(t, err, "error")
+}
+
 func TestEC2Tags(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -277,3 +471,48 @@ func TestEC2Tags(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchIMDSTags(t *testing.T) {
+	tags, err := fetchIMDSTags(context.Background(), &mockMetadata{retTags: map[string]string{"tag1": "val1", "tag2": "val2"}}, []*regexp.Regexp{regexp.MustCompile("^tag1$")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"tag1": "val1"}, tags)
+
+	_, err = fetchIMDSTags(context.Background(), &mockMetadata{retErrTags: errors.New("not enabled")}, []*regexp.Regexp{regexp.MustCompile(".*")})
+	assert.EqualError(t, err, "not enabled")
+}
+
+func TestEC2APIUsesHTTPClientSettings(t *testing.T) {
+	var lock sync.Mutex
+	var hosts []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		hosts = append(hosts, r.Host)
+		lock.Unlock()
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer proxy.Close()
+
+	// static credentials keep the SDK from looking them up in the instance metadata
+	for k, v := range map[string]string{"AWS_ACCESS_KEY_ID": "key", "AWS_SECRET_ACCESS_KEY": "secret"} {
+		old, ok := os.LookupEnv(k)
+		require.NoError(t, os.Setenv(k, v))
+		defer func(k, old string, ok bool) {
+			if ok {
+				os.Setenv(k, old)
+			} else {
+				os.Unsetenv(k)
+			}
+		}(k, old, ok)
+	}
+
+	client, err := internal.HTTPClientSettings{ProxyURL: proxy.URL}.ToClient()
+	require.NoError(t, err)
+
+	_, err = connectAndFetchEc2Tags("us-west-2", "i-1234", []*regexp.Regexp{regexp.MustCompile(".*")}, aws.NewConfig().WithHTTPClient(client))
+	assert.Error(t, err)
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.NotEmpty(t, hosts)
+	assert.Equal(t, "ec2.us-west-2.amazonaws.com:443", hosts[0])
+}
@@ -16,15 +16,41 @@ package ec2
 
 import (
 	"context"
+	"errors"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 )
 
+const (
+	// Headers of IMDSv2 requests, see
+	// https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/configuring-instance-metadata-service.html
+	imdsTokenHeader    = "X-Aws-Ec2-Metadata-Token"
+	imdsTokenTTLHeader = "X-Aws-Ec2-Metadata-Token-Ttl-Seconds"
+
+	requireIMDSv2HandlerName = "resourcedetection.RequireIMDSv2Handler"
+)
+
+var errIMDSv1Fallback = errors.New("no IMDSv2 session token could be acquired and fallback to IMDSv1 is disabled, " +
+	"the instance metadata response hop limit may be too low")
+
 type metadataProvider interface {
 	get(ctx context.Context) (ec2metadata.EC2InstanceIdentityDocument, error)
 	hostname(ctx context.Context) (string, error)
-	available(ctx context.Context) bool
+	availabilityZoneID(ctx context.Context) (string, error)
+	// lifecycle returns the purchasing option of the instance, e.g. spot or on-demand.
+	lifecycle(ctx context.Context) (string, error)
+	// available reports whether the instance metadata service is reachable. It only returns an error
+	// if the service is reachable but rejects the requests, i.e. if the fallback to IMDSv1 is disabled
+	// and no session token could be acquired.
+	available(ctx context.Context) (bool, error)
+	// tagKeys returns the keys of the instance tags. This fails unless access
+	// to instance tags in the instance metadata is enabled.
+	tagKeys(ctx context.Context) ([]string, error)
+	tagValue(ctx context.Context, key string) (string, error)
 }
 
 type metadataClient struct {
@@ -33,20 +59,68 @@ type metadataClient struct {
 
 var _ metadataProvider = (*metadataClient)(nil)
 
-func newMetadataClient(sess *session.Session) *metadataClient {
+func newMetadataClient(sess *session.Session, cfg Config) *metadataClient {
+	metadata := ec2metadata.New(sess, cfg.withRetries(aws.NewConfig()))
+	if cfg.FailOnIMDSv1Fallback {
+		// The SDK acquires the session token in a sign handler and silently falls back
+		// to IMDSv1 if that fails, so reject the unsigned requests afterwards.
+		metadata.Handlers.Sign.PushBackNamed(request.NamedHandler{
+			Name: requireIMDSv2HandlerName,
+			Fn:   requireIMDSv2Token,
+		})
+	}
+
 	return &metadataClient{
-		metadata: ec2metadata.New(sess),
+		metadata: metadata,
+	}
+}
+
+// requireIMDSv2Token fails any metadata request that is not signed with a session token,
+// other than the request acquiring the token itself.
+func requireIMDSv2Token(r *request.Request) {
+	if r.HTTPRequest.Header.Get(imdsTokenTTLHeader) != "" {
+		return
+	}
+	if r.HTTPRequest.Header.Get(imdsTokenHeader) == "" {
+		r.Error = errIMDSv1Fallback
 	}
 }
 
-func (c *metadataClient) available(ctx context.Context) bool {
-	return c.metadata.AvailableWithContext(ctx)
+func (c *metadataClient) available(ctx context.Context) (bool, error) {
+	// the same request as AvailableWithContext, which drops the error
+	if _, err := c.metadata.GetMetadataWithContext(ctx, "instance-id"); err != nil {
+		if errors.Is(err, errIMDSv1Fallback) {
+			return false, err
+		}
+		return false, nil
+	}
+	return true, nil
 }
 
 func (c *metadataClient) hostname(ctx context.Context) (string, error) {
 	return c.metadata.GetMetadataWithContext(ctx, "hostname")
 }
 
+func (c *metadataClient) availabilityZoneID(ctx context.Context) (string, error) {
+	return c.metadata.GetMetadataWithContext(ctx, "placement/availability-zone-id")
+}
+
+func (c *metadataClient) lifecycle(ctx context.Context) (string, error) {
+	return c.metadata.GetMetadataWithContext(ctx, "instance-life-cycle")
+}
+
 func (c *metadataClient) get(ctx context.Context) (ec2metadata.EC2InstanceIdentityDocument, error) {
 	return c.metadata.GetInstanceIdentityDocumentWithContext(ctx)
 }
+
+func (c *metadataClient) tagKeys(ctx context.Context) ([]string, error) {
+	keys, err := c.metadata.GetMetadataWithContext(ctx, "tags/instance")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(keys), nil
+}
+
+func (c *metadataClient) tagValue(ctx context.Context, key string) (string, error) {
+	return c.metadata.GetMetadataWithContext(ctx, "tags/instance/"+key)
+}
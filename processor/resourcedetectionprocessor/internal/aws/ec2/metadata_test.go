@@ -16,12 +16,20 @@ package ec2
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/awstesting/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 )
 
 func TestMetadataProvider_get(t *testing.T) {
@@ -47,7 +55,7 @@ func TestMetadataProvider_get(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := newMetadataClient(tt.args.sess)
+			c := newMetadataClient(tt.args.sess, Config{})
 			gotDoc, err := c.get(tt.args.ctx)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("get() error = %v, wantErr %v", err, tt.wantErr)
@@ -82,10 +90,83 @@ func TestMetadataProvider_available(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := newMetadataClient(tt.args.sess)
-			if got := c.available(tt.args.ctx); got != tt.want {
+			c := newMetadataClient(tt.args.sess, Config{})
+			got, err := c.available(tt.args.ctx)
+			assert.NoError(t, err)
+			if got != tt.want {
 				t.Errorf("available() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestMetadataProvider_availableTokenRequestFails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			// e.g. the response hop limit is too low for the token response to reach a container
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("i-abcd1234"))
+	}))
+	defer srv.Close()
+
+	sess, err := session.NewSession(aws.NewConfig().WithEndpoint(srv.URL))
+	require.NoError(t, err)
+
+	available, err := newMetadataClient(sess, Config{}).available(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, available)
+
+	available, err = newMetadataClient(sess, Config{FailOnIMDSv1Fallback: true}).available(context.Background())
+	assert.ErrorIs(t, err, errIMDSv1Fallback)
+	assert.False(t, available)
+}
+
+func TestRequireIMDSv2Token(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		wantErr error
+	}{
+		{
+			name:    "signed request",
+			headers: map[string]string{imdsTokenHeader: "token"},
+		},
+		{
+			name:    "token request",
+			headers: map[string]string{imdsTokenTTLHeader: "21600"},
+		},
+		{
+			name:    "IMDSv1 fallback",
+			wantErr: errIMDSv1Fallback,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpReq, err := http.NewRequest(http.MethodGet, "http://169.254.169.254/latest/meta-data/hostname", nil)
+			assert.NoError(t, err)
+			for k, v := range tt.headers {
+				httpReq.Header.Set(k, v)
+			}
+
+			r := &request.Request{HTTPRequest: httpReq}
+			requireIMDSv2Token(r)
+			assert.Equal(t, tt.wantErr, r.Error)
+		})
+	}
+}
+
+func TestNewMetadataClient_FailOnIMDSv1Fallback(t *testing.T) {
+	c := newMetadataClient(mock.Session, Config{FailOnIMDSv1Fallback: true, MaxRetries: 5})
+	assert.Equal(t, 5, *c.metadata.Config.MaxRetries)
+	assert.True(t, c.metadata.Handlers.Sign.Swap(requireIMDSv2HandlerName, request.NamedHandler{Name: requireIMDSv2HandlerName, Fn: requireIMDSv2Token}))
+}
+
+func TestNewMetadataClient_Retry(t *testing.T) {
+	c := newMetadataClient(mock.Session, Config{
+		MaxRetries:         5,
+		HTTPClientSettings: internal.HTTPClientSettings{Retry: internal.RetrySettings{MaxAttempts: 3}},
+	})
+	assert.Equal(t, 2, *c.metadata.Config.MaxRetries)
+}
@@ -33,6 +33,8 @@ const (
 	TypeStr     = "ecs"
 	tmde3EnvVar = "ECS_CONTAINER_METADATA_URI"
 	tmde4EnvVar = "ECS_CONTAINER_METADATA_URI_V4"
+
+	attributeAWSECSServiceName = "aws.ecs.service.name"
 )
 
 var _ internal.Detector = (*Detector)(nil)
@@ -88,7 +90,7 @@ func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
 		attr.InsertString(conventions.AttributeCloudAvailabilityZone, tmdeResp.AvailabilityZone)
 	}
 
-	// The launch type and log data attributes are only available in TMDE v4
+	// The launch type, service name and log data attributes are only available in TMDE v4
 	switch lt := strings.ToLower(tmdeResp.LaunchType); lt {
 	case "ec2":
 		attr.InsertString(conventions.AttributeAWSECSLaunchType, "ec2")
@@ -97,12 +99,21 @@ func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
 		attr.InsertString(conventions.AttributeAWSECSLaunchType, "fargate")
 	}
 
+	if tmdeResp.ServiceName != "" {
+		attr.InsertString(attributeAWSECSServiceName, tmdeResp.ServiceName)
+	}
+
 	selfMetaData, err := d.provider.fetchContainerMetaData(tmde)
 
 	if err != nil || selfMetaData == nil {
 		return res, err
 	}
 
+	// The container ARN is only available in TMDE v4
+	if selfMetaData.ContainerARN != "" {
+		attr.InsertString(conventions.AttributeAWSECSContainerARN, selfMetaData.ContainerARN)
+	}
+
 	logAttributes := [4]string{
 		conventions.AttributeAWSLogGroupNames,
 		conventions.AttributeAWSLogGroupARNs,
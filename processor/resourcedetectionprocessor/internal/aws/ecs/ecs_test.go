@@ -48,6 +48,7 @@ func (md *mockMetaDataProvider) fetchTaskMetaData(tmde string) (*TaskMetaData, e
 
 	if md.isV4 {
 		tmd.LaunchType = "EC2"
+		tmd.ServiceName = "my-service"
 	}
 
 	return tmd, nil
@@ -124,6 +125,8 @@ func Test_ecsDetectV4(t *testing.T) {
 	attr.InsertString("cloud.availability_zone", "us-west-2a")
 	attr.InsertString("cloud.account.id", "123456789123")
 	attr.InsertString("aws.ecs.launchtype", "ec2")
+	attr.InsertString("aws.ecs.service.name", "my-service")
+	attr.InsertString("aws.ecs.container.arn", "arn:aws:ecs")
 
 	attribFields := []string{"aws.log.group.names", "aws.log.group.arns", "aws.log.stream.names", "aws.log.stream.arns"}
 	attribVals := []string{"group", "arn:aws:logs:us-east-1:123456789123:log-group:group", "stream", "arn:aws:logs:us-east-1:123456789123:log-group:group:log-stream:stream"}
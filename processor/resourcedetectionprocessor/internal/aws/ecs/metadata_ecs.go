@@ -28,6 +28,7 @@ type TaskMetaData struct {
 	Family           string
 	AvailabilityZone string
 	Revision         string
+	ServiceName      string // only available in TMDE v4 for tasks that are part of a service
 	Containers       []Container
 }
 
@@ -32,6 +32,7 @@ const (
 		"LaunchType":"ec2",
 		"AvailabilityZone":"ap-southeast-1a",
 		"Revision":"26",
+		"ServiceName":"myService",
 		"Containers": []
 	}`
 
@@ -77,6 +78,7 @@ func Test_ecsMetadata_fetchTask(t *testing.T) {
 	assert.Equal(t, "ec2", fetchResp.LaunchType)
 	assert.Equal(t, "ap-southeast-1a", fetchResp.AvailabilityZone)
 	assert.Equal(t, "26", fetchResp.Revision)
+	assert.Equal(t, "myService", fetchResp.ServiceName)
 	assert.Empty(t, fetchResp.Containers)
 }
 
@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+const (
+	// Tag that EKS managed node groups and eksctl set on the EC2 instances of a cluster
+	clusterNameTagKey = "aws:eks:cluster-name"
+	// Prefix of the tag that is set on the EC2 instances of a cluster, see
+	// https://docs.aws.amazon.com/eks/latest/userguide/eks-networking.html
+	clusterOwnershipTagPrefix = "kubernetes.io/cluster/"
+
+	cloudWatchAgentNamespace  = "amazon-cloudwatch"
+	cloudWatchAgentConfigMap  = "cwagentconfig"
+	cloudWatchAgentConfigFile = "cwagentconfig.json"
+)
+
+// clusterNameStrategy is a way of determining the name of the EKS cluster
+type clusterNameStrategy struct {
+	name        string
+	clusterName func(ctx context.Context) (string, error)
+}
+
+// clusterNameStrategies returns the strategies with the given names, in order.
+func clusterNameStrategies(names []string, sess *session.Session, metadata *ec2metadata.EC2Metadata) ([]clusterNameStrategy, error) {
+	if len(names) == 0 {
+		names = []string{ClusterNameStrategyEC2Tags, ClusterNameStrategyEKSAPI, ClusterNameStrategyConfigMap}
+	}
+
+	strategies := make([]clusterNameStrategy, 0, len(names))
+	for _, name := range names {
+		var get func(ctx context.Context) (string, error)
+		switch name {
+		case ClusterNameStrategyEKSAPI:
+			get = func(ctx context.Context) (string, error) {
+				return clusterNameFromEKSAPI(ctx, sess, metadata)
+			}
+		case ClusterNameStrategyEC2Tags:
+			get = func(ctx context.Context) (string, error) {
+				return clusterNameFromEC2Tags(ctx, sess, metadata)
+			}
+		case ClusterNameStrategyConfigMap:
+			get = (&configMapClusterName{}).clusterName
+		default:
+			return nil, fmt.Errorf("invalid cluster name strategy %q", name)
+		}
+		strategies = append(strategies, clusterNameStrategy{name: name, clusterName: get})
+	}
+	return strategies, nil
+}
+
+func clusterNameFromEKSAPI(ctx context.Context, sess *session.Session, metadata *ec2metadata.EC2Metadata) (string, error) {
+	region, err := metadata.RegionWithContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed getting region: %w", err)
+	}
+
+	mac, err := metadata.GetMetadataWithContext(ctx, "mac")
+	if err != nil {
+		return "", fmt.Errorf("failed getting MAC address: %w", err)
+	}
+	vpcID, err := metadata.GetMetadataWithContext(ctx, "network/interfaces/macs/"+mac+"/vpc-id")
+	if err != nil {
+		return "", fmt.Errorf("failed getting VPC ID: %w", err)
+	}
+
+	client := eks.New(sess, aws.NewConfig().WithRegion(region))
+	list, err := client.ListClustersWithContext(ctx, &eks.ListClustersInput{})
+	if err != nil {
+		return "", err
+	}
+
+	clusters := make([]*eks.Cluster, 0, len(list.Clusters))
+	for _, name := range list.Clusters {
+		out, err := client.DescribeClusterWithContext(ctx, &eks.DescribeClusterInput{Name: name})
+		if err != nil {
+			return "", err
+		}
+		clusters = append(clusters, out.Cluster)
+	}
+	return activeClusterInVPC(clusters, vpcID)
+}
+
+// activeClusterInVPC returns the name of the only active cluster in the VPC of the node. Clusters
+// that are being created or deleted, or that are in other VPCs, cannot be the node's cluster.
+func activeClusterInVPC(clusters []*eks.Cluster, vpcID string) (string, error) {
+	var names []string
+	for _, cluster := range clusters {
+		if cluster == nil || cluster.ResourcesVpcConfig == nil {
+			continue
+		}
+		if aws.StringValue(cluster.Status) == eks.ClusterStatusActive && aws.StringValue(cluster.ResourcesVpcConfig.VpcId) == vpcID {
+			names = append(names, aws.StringValue(cluster.Name))
+		}
+	}
+	if len(names) != 1 {
+		return "", fmt.Errorf("found %d active clusters in VPC %s, cannot determine which one the node belongs to", len(names), vpcID)
+	}
+	return names[0], nil
+}
+
+func clusterNameFromEC2Tags(ctx context.Context, sess *session.Session, metadata *ec2metadata.EC2Metadata) (string, error) {
+	doc, err := metadata.GetInstanceIdentityDocumentWithContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed getting identity document: %w", err)
+	}
+
+	tags, err := ec2.New(sess, aws.NewConfig().WithRegion(doc.Region)).DescribeTagsWithContext(ctx, &ec2.DescribeTagsInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("resource-id"),
+			Values: []*string{aws.String(doc.InstanceID)},
+		}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	keys := make([]string, 0, len(tags.Tags))
+	for _, tag := range tags.Tags {
+		keys = append(keys, aws.StringValue(tag.Key))
+		if aws.StringValue(tag.Key) == clusterNameTagKey {
+			return aws.StringValue(tag.Value), nil
+		}
+	}
+	return clusterNameFromTagKeys(keys)
+}
+
+// clusterNameFromTagKeys returns the cluster name from a kubernetes.io/cluster/<name> tag.
+func clusterNameFromTagKeys(keys []string) (string, error) {
+	for _, key := range keys {
+		if strings.HasPrefix(key, clusterOwnershipTagPrefix) {
+			return strings.TrimPrefix(key, clusterOwnershipTagPrefix), nil
+		}
+	}
+	return "", errors.New("no cluster name tag found on the EC2 instance")
+}
+
+// configMapClusterName reads the cluster name from the CloudWatch agent configmap. The kubernetes
+// client is created on first use and reused by later detections.
+type configMapClusterName struct {
+	lock   sync.Mutex
+	client kubernetes.Interface
+}
+
+func (c *configMapClusterName) clusterName(ctx context.Context) (string, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return "", err
+	}
+
+	cm, err := client.CoreV1().ConfigMaps(cloudWatchAgentNamespace).Get(ctx, cloudWatchAgentConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return parseCloudWatchAgentConfig(cm.Data[cloudWatchAgentConfigFile])
+}
+
+func (c *configMapClusterName) getClient() (kubernetes.Interface, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.client == nil {
+		client, err := k8sconfig.MakeClient(k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount})
+		if err != nil {
+			return nil, err
+		}
+		c.client = client
+	}
+	return c.client, nil
+}
+
+// cloudWatchAgentConfig is the subset of the CloudWatch agent configuration holding the cluster name
+type cloudWatchAgentConfig struct {
+	Logs struct {
+		MetricsCollected struct {
+			Kubernetes struct {
+				ClusterName string `json:"cluster_name"`
+			} `json:"kubernetes"`
+		} `json:"metrics_collected"`
+	} `json:"logs"`
+}
+
+func parseCloudWatchAgentConfig(data string) (string, error) {
+	var cfg cloudWatchAgentConfig
+	if err := json.Unmarshal([]byte(data), &cfg); err != nil {
+		return "", fmt.Errorf("failed parsing CloudWatch agent configuration: %w", err)
+	}
+
+	clusterName := cfg.Logs.MetricsCollected.Kubernetes.ClusterName
+	if clusterName == "" {
+		return "", errors.New("no cluster name found in CloudWatch agent configuration")
+	}
+	return clusterName, nil
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+const (
+	// ClusterNameStrategyEKSAPI describes the EKS clusters in the region using the node IAM role,
+	// and uses the cluster name if exactly one active cluster is in the VPC of the node.
+	ClusterNameStrategyEKSAPI = "eks_api"
+	// ClusterNameStrategyEC2Tags reads the cluster name from the tags of the EC2 instance.
+	ClusterNameStrategyEC2Tags = "ec2_tags"
+	// ClusterNameStrategyConfigMap reads the cluster name from the CloudWatch agent configmap.
+	ClusterNameStrategyConfigMap = "configmap"
+)
+
+// Config defines user-specified configurations unique to the EKS detector
+type Config struct {
+	// ClusterNameStrategies is the ordered list of strategies used to determine the
+	// name of the EKS cluster. The first strategy that succeeds wins. Defaults to
+	// all strategies, in the order ec2_tags, eks_api, configmap.
+	ClusterNameStrategies []string `mapstructure:"cluster_name_strategies"`
+}
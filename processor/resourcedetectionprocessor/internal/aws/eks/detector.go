@@ -18,9 +18,12 @@ import (
 	"context"
 	"os"
 
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 )
@@ -31,16 +34,37 @@ const (
 
 	// Environment variable that is set when running on Kubernetes.
 	kubernetesServiceHostEnvVar = "KUBERNETES_SERVICE_HOST"
+
+	attributeCloudAvailabilityZoneID = "cloud.availability_zone.id"
 )
 
 var _ internal.Detector = (*Detector)(nil)
 
+// metadataProvider is the subset of the EC2 instance metadata client used by the detector.
+type metadataProvider interface {
+	GetMetadataWithContext(ctx context.Context, p string) (string, error)
+}
+
 // Detector for EKS
-type Detector struct{}
+type Detector struct {
+	logger     *zap.Logger
+	metadata   metadataProvider
+	strategies []clusterNameStrategy
+}
 
 // NewDetector returns a resource detector that will detect AWS EKS resources.
-func NewDetector(_ component.ProcessorCreateParams, _ internal.DetectorConfig) (internal.Detector, error) {
-	return &Detector{}, nil
+func NewDetector(params component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	metadata := ec2metadata.New(sess)
+	strategies, err := clusterNameStrategies(cfg.ClusterNameStrategies, sess, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &Detector{logger: params.Logger, metadata: metadata, strategies: strategies}, nil
 }
 
 // Detect returns a Resource describing the Amazon EKS environment being run in.
@@ -56,5 +80,44 @@ func (detector *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
 	attr.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
 	attr.InsertString(conventions.AttributeCloudPlatform, conventions.AttributeCloudPlatformAWSEKS)
 
+	if clusterName := detector.clusterName(ctx); clusterName != "" {
+		attr.InsertString(conventions.AttributeK8sCluster, clusterName)
+	}
+
+	if detector.metadata != nil {
+		detector.insertAvailabilityZone(ctx, attr)
+	}
+
 	return res, nil
 }
+
+// insertAvailabilityZone adds the availability zone name and ID of the node from the instance
+// metadata. They are not available on Fargate, in which case they are left out.
+func (detector *Detector) insertAvailabilityZone(ctx context.Context, attr pdata.AttributeMap) {
+	zone, err := detector.metadata.GetMetadataWithContext(ctx, "placement/availability-zone")
+	if err != nil {
+		detector.logger.Debug("Failed getting availability zone", zap.Error(err))
+		return
+	}
+	attr.InsertString(conventions.AttributeCloudAvailabilityZone, zone)
+
+	zoneID, err := detector.metadata.GetMetadataWithContext(ctx, "placement/availability-zone-id")
+	if err != nil {
+		detector.logger.Debug("Failed getting availability zone ID", zap.Error(err))
+		return
+	}
+	attr.InsertString(attributeCloudAvailabilityZoneID, zoneID)
+}
+
+// clusterName tries the configured strategies in order, and returns the first cluster name
+// found. It returns an empty string if none of them succeeds.
+func (detector *Detector) clusterName(ctx context.Context) string {
+	for _, strategy := range detector.strategies {
+		clusterName, err := strategy.clusterName(ctx)
+		if err == nil && clusterName != "" {
+			return clusterName
+		}
+		detector.logger.Debug("Failed getting EKS cluster name", zap.String("strategy", strategy.name), zap.Error(err))
+	}
+	return ""
+}
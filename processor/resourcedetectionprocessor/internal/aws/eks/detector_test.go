@@ -16,9 +16,12 @@ package eks
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
@@ -27,12 +30,104 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 )
 
+type mockMetadata map[string]string
+
+func (m mockMetadata) GetMetadataWithContext(_ context.Context, p string) (string, error) {
+	if v, ok := m[p]; ok {
+		return v, nil
+	}
+	return "", errors.New("not found")
+}
+
 func TestNewDetector(t *testing.T) {
-	detector, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, nil)
+	detector, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
 	assert.NoError(t, err)
 	assert.NotNil(t, detector)
 }
 
+func TestNewDetectorInvalidStrategy(t *testing.T) {
+	_, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{ClusterNameStrategies: []string{"ec2_tags", "invalid"}})
+	assert.EqualError(t, err, `invalid cluster name strategy "invalid"`)
+}
+
+// Tests that the cluster name is taken from the first strategy that succeeds
+func TestEKSClusterName(t *testing.T) {
+	require.NoError(t, os.Setenv("KUBERNETES_SERVICE_HOST", "localhost"))
+	defer os.Unsetenv("KUBERNETES_SERVICE_HOST")
+
+	var called []string
+	strategy := func(name, clusterName string, err error) clusterNameStrategy {
+		return clusterNameStrategy{name: name, clusterName: func(context.Context) (string, error) {
+			called = append(called, name)
+			return clusterName, err
+		}}
+	}
+
+	detector := &Detector{logger: zap.NewNop(), metadata: mockMetadata{}, strategies: []clusterNameStrategy{
+		strategy(ClusterNameStrategyEKSAPI, "", errors.New("found 2 clusters")),
+		strategy(ClusterNameStrategyEC2Tags, "my-cluster", nil),
+		strategy(ClusterNameStrategyConfigMap, "other-cluster", nil),
+	}}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":   "aws",
+		"cloud.platform":   "aws_eks",
+		"k8s.cluster.name": "my-cluster",
+	}, internal.AttributesToMap(res.Attributes()))
+	assert.Equal(t, []string{ClusterNameStrategyEKSAPI, ClusterNameStrategyEC2Tags}, called)
+}
+
+func TestClusterNameFromTagKeys(t *testing.T) {
+	name, err := clusterNameFromTagKeys([]string{"Name", "kubernetes.io/cluster/my-cluster"})
+	require.NoError(t, err)
+	assert.Equal(t, "my-cluster", name)
+
+	_, err = clusterNameFromTagKeys([]string{"Name"})
+	assert.Error(t, err)
+}
+
+func TestActiveClusterInVPC(t *testing.T) {
+	cluster := func(name, status, vpcID string) *eks.Cluster {
+		return &eks.Cluster{
+			Name:               aws.String(name),
+			Status:             aws.String(status),
+			ResourcesVpcConfig: &eks.VpcConfigResponse{VpcId: aws.String(vpcID)},
+		}
+	}
+
+	name, err := activeClusterInVPC([]*eks.Cluster{
+		cluster("other-vpc", eks.ClusterStatusActive, "vpc-2"),
+		cluster("deleting", eks.ClusterStatusDeleting, "vpc-1"),
+		cluster("my-cluster", eks.ClusterStatusActive, "vpc-1"),
+		cluster("creating", eks.ClusterStatusCreating, "vpc-1"),
+	}, "vpc-1")
+	require.NoError(t, err)
+	assert.Equal(t, "my-cluster", name)
+
+	_, err = activeClusterInVPC([]*eks.Cluster{cluster("other-vpc", eks.ClusterStatusActive, "vpc-2")}, "vpc-1")
+	assert.EqualError(t, err, "found 0 active clusters in VPC vpc-1, cannot determine which one the node belongs to")
+
+	_, err = activeClusterInVPC([]*eks.Cluster{
+		cluster("a", eks.ClusterStatusActive, "vpc-1"),
+		cluster("b", eks.ClusterStatusActive, "vpc-1"),
+	}, "vpc-1")
+	assert.EqualError(t, err, "found 2 active clusters in VPC vpc-1, cannot determine which one the node belongs to")
+}
+
+func TestParseCloudWatchAgentConfig(t *testing.T) {
+	name, err := parseCloudWatchAgentConfig(`{"logs": {"metrics_collected": {"kubernetes": {"cluster_name": "my-cluster"}}}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "my-cluster", name)
+
+	_, err = parseCloudWatchAgentConfig(`{"logs": {}}`)
+	assert.EqualError(t, err, "no cluster name found in CloudWatch agent configuration")
+
+	_, err = parseCloudWatchAgentConfig(`{`)
+	assert.Error(t, err)
+}
+
 // Tests EKS resource detector running in EKS environment
 func TestEKS(t *testing.T) {
 	ctx := context.Background()
@@ -40,13 +135,18 @@ func TestEKS(t *testing.T) {
 	require.NoError(t, os.Setenv("KUBERNETES_SERVICE_HOST", "localhost"))
 
 	// Call EKS Resource detector to detect resources
-	eksResourceDetector := &Detector{}
+	eksResourceDetector := &Detector{logger: zap.NewNop(), metadata: mockMetadata{
+		"placement/availability-zone":    "us-east-1a",
+		"placement/availability-zone-id": "use1-az2",
+	}}
 	res, err := eksResourceDetector.Detect(ctx)
 	require.NoError(t, err)
 
 	assert.Equal(t, map[string]interface{}{
-		"cloud.provider": "aws",
-		"cloud.platform": "aws_eks",
+		"cloud.provider":             "aws",
+		"cloud.platform":             "aws_eks",
+		"cloud.availability_zone":    "us-east-1a",
+		"cloud.availability_zone.id": "use1-az2",
 	}, internal.AttributesToMap(res.Attributes()), "Resource object returned is incorrect")
 }
 
@@ -17,6 +17,7 @@ package aks
 import (
 	"context"
 	"os"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
@@ -31,15 +32,24 @@ const (
 
 	// Environment variable that is set when running on Kubernetes
 	kubernetesServiceHostEnvVar = "KUBERNETES_SERVICE_HOST"
+
+	// Prefix of the resource group AKS creates for the nodes of a cluster
+	nodeResourceGroupPrefix = "MC_"
 )
 
 type Detector struct {
-	provider azure.Provider
+	provider    azure.Provider
+	clusterName string
 }
 
 // NewDetector creates a new AKS detector
-func NewDetector(component.ProcessorCreateParams, internal.DetectorConfig) (internal.Detector, error) {
-	return &Detector{provider: azure.NewProvider()}, nil
+func NewDetector(_ component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	provider, err := azure.NewProviderFromSettings(cfg.HTTPClientSettings)
+	if err != nil {
+		return nil, err
+	}
+	return &Detector{provider: provider, clusterName: cfg.ClusterName}, nil
 }
 
 func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
@@ -50,7 +60,8 @@ func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
 	}
 
 	// If we can't get a response from the metadata endpoint, we're not running in Azure
-	if !azureMetadataAvailable(ctx, d.provider) {
+	compute, err := d.provider.Metadata(ctx)
+	if err != nil {
 		return res, nil
 	}
 
@@ -58,14 +69,42 @@ func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
 	attrs.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAzure)
 	attrs.InsertString(conventions.AttributeCloudPlatform, conventions.AttributeCloudPlatformAzureAKS)
 
+	clusterName := d.clusterName
+	if clusterName == "" {
+		clusterName = parseClusterName(compute.ResourceGroupName, compute.Location)
+	}
+	if clusterName != "" {
+		attrs.InsertString(conventions.AttributeK8sCluster, clusterName)
+	}
+
 	return res, nil
 }
 
-func onK8s() bool {
-	return os.Getenv(kubernetesServiceHostEnvVar) != ""
+// parseClusterName extracts the cluster name from the name of the node resource group,
+// which AKS creates following the MC_<resource group>_<cluster name>_<region> convention.
+// Returns an empty string if the resource group does not follow the convention, e.g.
+// because a custom node resource group name was set when creating the cluster.
+func parseClusterName(resourceGroup, location string) string {
+	if !strings.HasPrefix(resourceGroup, nodeResourceGroupPrefix) {
+		return ""
+	}
+	name := strings.TrimPrefix(resourceGroup, nodeResourceGroupPrefix)
+
+	locationSuffix := "_" + location
+	if location == "" || !strings.HasSuffix(strings.ToLower(name), strings.ToLower(locationSuffix)) {
+		return ""
+	}
+	name = name[:len(name)-len(locationSuffix)]
+
+	// Resource group names may contain underscores, so the cluster name is
+	// everything after the last one.
+	i := strings.LastIndex(name, "_")
+	if i <= 0 || i == len(name)-1 {
+		return ""
+	}
+	return name[i+1:]
 }
 
-func azureMetadataAvailable(ctx context.Context, p azure.Provider) bool {
-	_, err := p.Metadata(ctx)
-	return err == nil
+func onK8s() bool {
+	return os.Getenv(kubernetesServiceHostEnvVar) != ""
 }
@@ -30,7 +30,7 @@ import (
 )
 
 func TestNewDetector(t *testing.T) {
-	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, nil)
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
 	require.NoError(t, err)
 	assert.NotNil(t, d)
 }
@@ -47,6 +47,61 @@ func TestDetector_Detect_K8s_Azure(t *testing.T) {
 	}, internal.AttributesToMap(res.Attributes()), "Resource attrs returned are incorrect")
 }
 
+func TestDetector_Detect_K8s_Azure_ClusterName(t *testing.T) {
+	os.Clearenv()
+	setK8sEnv(t)
+	mp := &azure.MockProvider{}
+	mp.On("Metadata").Return(&azure.ComputeMetadata{
+		ResourceGroupName: "MC_my_resource_group_my-cluster_westeurope",
+		Location:          "westeurope",
+	}, nil)
+	detector := &Detector{provider: mp}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":   "azure",
+		"cloud.platform":   "azure_aks",
+		"k8s.cluster.name": "my-cluster",
+	}, internal.AttributesToMap(res.Attributes()), "Resource attrs returned are incorrect")
+}
+
+func TestDetector_Detect_K8s_Azure_ClusterNameOverride(t *testing.T) {
+	os.Clearenv()
+	setK8sEnv(t)
+	mp := &azure.MockProvider{}
+	mp.On("Metadata").Return(&azure.ComputeMetadata{
+		ResourceGroupName: "MC_rg_my-cluster_westeurope",
+		Location:          "westeurope",
+	}, nil)
+	detector := &Detector{provider: mp, clusterName: "configured"}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	v, ok := res.Attributes().Get("k8s.cluster.name")
+	require.True(t, ok)
+	assert.Equal(t, "configured", v.StringVal())
+}
+
+func TestParseClusterName(t *testing.T) {
+	tests := []struct {
+		resourceGroup string
+		location      string
+		expected      string
+	}{
+		{resourceGroup: "MC_rg_cluster_eastus", location: "eastus", expected: "cluster"},
+		{resourceGroup: "MC_my_rg_cluster_eastus", location: "eastus", expected: "cluster"},
+		{resourceGroup: "MC_rg_cluster_EastUS", location: "eastus", expected: "cluster"},
+		{resourceGroup: "custom-node-rg", location: "eastus", expected: ""},
+		{resourceGroup: "MC_rg_cluster_westus", location: "eastus", expected: ""},
+		{resourceGroup: "MC_cluster_eastus", location: "eastus", expected: ""},
+		{resourceGroup: "MC_rg_cluster_eastus", location: "", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.resourceGroup, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseClusterName(tt.resourceGroup, tt.location))
+		})
+	}
+}
+
 func TestDetector_Detect_K8s_NonAzure(t *testing.T) {
 	os.Clearenv()
 	setK8sEnv(t)
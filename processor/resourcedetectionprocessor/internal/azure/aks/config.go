@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aks
+
+import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+
+// Config defines user-specified configurations unique to the AKS detector
+type Config struct {
+	// ClusterName overrides the name of the AKS cluster. If empty, the cluster
+	// name is derived from the name of the node resource group.
+	ClusterName string `mapstructure:"cluster_name"`
+
+	// HTTPClientSettings configures the proxy, TLS and retry settings of the requests to the Azure IMDS
+	internal.HTTPClientSettings `mapstructure:",squash"`
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package appservice provides a detector for Azure App Service web apps and
+// Azure Functions function apps, based on the environment variables set by the platform.
+package appservice
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "azure_app_service"
+
+	attributeCloudResourceID = "cloud.resource_id"
+
+	// Environment variables set by Azure App Service,
+	// see https://docs.microsoft.com/en-us/azure/app-service/reference-app-settings
+	siteNameEnvVar      = "WEBSITE_SITE_NAME"
+	resourceGroupEnvVar = "WEBSITE_RESOURCE_GROUP"
+	ownerNameEnvVar     = "WEBSITE_OWNER_NAME"
+	instanceIDEnvVar    = "WEBSITE_INSTANCE_ID"
+	regionNameEnvVar    = "REGION_NAME"
+	regionEnvVar        = "REGION"
+	// Set on function apps only
+	functionsRuntimeEnvVar = "FUNCTIONS_WORKER_RUNTIME"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector for Azure App Service and Azure Functions
+type Detector struct{}
+
+// NewDetector returns a resource detector that will detect Azure App Service resources.
+func NewDetector(component.ProcessorCreateParams, internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{}, nil
+}
+
+// Detect returns a Resource describing the web or function app being run in.
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	siteName := os.Getenv(siteNameEnvVar)
+	if siteName == "" {
+		// not running on Azure App Service
+		return res, nil
+	}
+
+	attrs := res.Attributes()
+	attrs.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAzure)
+	if os.Getenv(functionsRuntimeEnvVar) != "" {
+		attrs.InsertString(conventions.AttributeCloudPlatform, conventions.AttributeCloudPlatformAzureFunctions)
+	} else {
+		attrs.InsertString(conventions.AttributeCloudPlatform, conventions.AttributeCloudPlatformAzureAppService)
+	}
+	attrs.InsertString(conventions.AttributeFaasName, siteName)
+
+	if instanceID := os.Getenv(instanceIDEnvVar); instanceID != "" {
+		attrs.InsertString(conventions.AttributeFaasInstance, instanceID)
+	}
+
+	region := os.Getenv(regionNameEnvVar)
+	if region == "" {
+		region = os.Getenv(regionEnvVar)
+	}
+	if region != "" {
+		attrs.InsertString(conventions.AttributeCloudRegion, region)
+	}
+
+	subscriptionID := subscriptionFromOwnerName(os.Getenv(ownerNameEnvVar))
+	if subscriptionID != "" {
+		attrs.InsertString(conventions.AttributeCloudAccount, subscriptionID)
+		if resourceGroup := os.Getenv(resourceGroupEnvVar); resourceGroup != "" {
+			attrs.InsertString(attributeCloudResourceID, fmt.Sprintf(
+				"/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Web/sites/%s", subscriptionID, resourceGroup, siteName))
+		}
+	}
+
+	return res, nil
+}
+
+// subscriptionFromOwnerName returns the subscription ID from WEBSITE_OWNER_NAME,
+// which has the format <subscription ID>+<resource group>-<region>webspace.
+func subscriptionFromOwnerName(ownerName string) string {
+	if i := strings.Index(ownerName, "+"); i > 0 {
+		return ownerName[:i]
+	}
+	return ""
+}
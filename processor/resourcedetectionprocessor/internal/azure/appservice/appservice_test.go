@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appservice
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+func setEnv(t *testing.T, env map[string]string) {
+	for k, v := range env {
+		require.NoError(t, os.Setenv(k, v))
+		key := k
+		t.Cleanup(func() { os.Unsetenv(key) })
+	}
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetectAppService(t *testing.T) {
+	setEnv(t, map[string]string{
+		siteNameEnvVar:      "my-web-app",
+		resourceGroupEnvVar: "my-resource-group",
+		ownerNameEnvVar:     "00000000-0000-0000-0000-000000000000+my-resource-group-EastUSwebspace",
+		instanceIDEnvVar:    "instance-1",
+		regionNameEnvVar:    "East US",
+	})
+
+	res, err := (&Detector{}).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":    "azure",
+		"cloud.platform":    "azure_app_service",
+		"cloud.region":      "East US",
+		"cloud.account.id":  "00000000-0000-0000-0000-000000000000",
+		"cloud.resource_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-resource-group/providers/Microsoft.Web/sites/my-web-app",
+		"faas.name":         "my-web-app",
+		"faas.instance":     "instance-1",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectFunctionApp(t *testing.T) {
+	setEnv(t, map[string]string{
+		siteNameEnvVar:         "my-function-app",
+		regionEnvVar:           "westeurope",
+		functionsRuntimeEnvVar: "python",
+	})
+
+	res, err := (&Detector{}).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider": "azure",
+		"cloud.platform": "azure_functions",
+		"cloud.region":   "westeurope",
+		"faas.name":      "my-function-app",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectNotAppService(t *testing.T) {
+	res, err := (&Detector{}).Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
@@ -16,6 +16,7 @@ package azure
 
 import (
 	"context"
+	"regexp"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
@@ -28,21 +29,35 @@ import (
 const (
 	// TypeStr is the detector type string
 	TypeStr = "azure"
+
+	tagPrefix = "azure.tag."
 )
 
 var _ internal.Detector = (*Detector)(nil)
 
 // Detector is an Azure metadata detector
 type Detector struct {
-	provider Provider
-	logger   *zap.Logger
+	provider      Provider
+	logger        *zap.Logger
+	tagKeyRegexes []*regexp.Regexp
 }
 
 // NewDetector creates a new Azure metadata detector
-func NewDetector(p component.ProcessorCreateParams, cfg internal.DetectorConfig) (internal.Detector, error) {
+func NewDetector(p component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	tagKeyRegexes, err := compileRegexes(cfg)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := NewProviderFromSettings(cfg.HTTPClientSettings)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Detector{
-		provider: NewProvider(),
-		logger:   p.Logger,
+		provider:      provider,
+		logger:        p.Logger,
+		tagKeyRegexes: tagKeyRegexes,
 	}, nil
 }
 
@@ -67,6 +82,39 @@ func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
 	attrs.InsertString("azure.vm.size", compute.VMSize)
 	attrs.InsertString("azure.vm.scaleset.name", compute.VMScaleSetName)
 	attrs.InsertString("azure.resourcegroup.name", compute.ResourceGroupName)
+	if compute.FaultDomain != "" {
+		attrs.InsertString("azure.vm.faultdomain", compute.FaultDomain)
+	}
+	if compute.UpdateDomain != "" {
+		attrs.InsertString("azure.vm.updatedomain", compute.UpdateDomain)
+	}
+
+	for _, tag := range compute.TagsList {
+		if regexArrayMatch(d.tagKeyRegexes, tag.Name) {
+			attrs.InsertString(tagPrefix+tag.Name, tag.Value)
+		}
+	}
 
 	return res, nil
 }
+
+func compileRegexes(cfg Config) ([]*regexp.Regexp, error) {
+	tagRegexes := make([]*regexp.Regexp, len(cfg.Tags))
+	for i, elem := range cfg.Tags {
+		regex, err := regexp.Compile(elem)
+		if err != nil {
+			return nil, err
+		}
+		tagRegexes[i] = regex
+	}
+	return tagRegexes, nil
+}
+
+func regexArrayMatch(arr []*regexp.Regexp, val string) bool {
+	for _, elem := range arr {
+		if elem.MatchString(val) {
+			return true
+		}
+	}
+	return false
+}
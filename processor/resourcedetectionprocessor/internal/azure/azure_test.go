@@ -17,6 +17,7 @@ package azure
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -29,11 +30,16 @@ import (
 )
 
 func TestNewDetector(t *testing.T) {
-	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, nil)
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
 	require.NoError(t, err)
 	assert.NotNil(t, d)
 }
 
+func TestNewDetectorInvalidTagRegex(t *testing.T) {
+	_, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{Tags: []string{"*"}})
+	assert.Error(t, err)
+}
+
 func TestDetectAzureAvailable(t *testing.T) {
 	mp := &MockProvider{}
 	mp.On("Metadata").Return(&ComputeMetadata{
@@ -44,9 +50,15 @@ func TestDetectAzureAvailable(t *testing.T) {
 		SubscriptionID:    "subscriptionID",
 		ResourceGroupName: "resourceGroup",
 		VMScaleSetName:    "myScaleset",
+		FaultDomain:       "1",
+		UpdateDomain:      "4",
+		TagsList: []Tag{
+			{Name: "environment", Value: "prod"},
+			{Name: "team", Value: "observability"},
+		},
 	}, nil)
 
-	detector := &Detector{provider: mp}
+	detector := &Detector{provider: mp, tagKeyRegexes: []*regexp.Regexp{regexp.MustCompile("^env")}}
 	res, err := detector.Detect(context.Background())
 	require.NoError(t, err)
 	mp.AssertExpectations(t)
@@ -62,6 +74,9 @@ func TestDetectAzureAvailable(t *testing.T) {
 		"azure.vm.size":                    "vmSize",
 		"azure.resourcegroup.name":         "resourceGroup",
 		"azure.vm.scaleset.name":           "myScaleset",
+		"azure.vm.faultdomain":             "1",
+		"azure.vm.updatedomain":            "4",
+		"azure.tag.environment":            "prod",
 	})
 	expected.Attributes().Sort()
 
@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+
+// Config defines user-specified configurations unique to the Azure detector
+type Config struct {
+	// Tags is a list of regex's to match Azure VM tag names that users want
+	// to add as resource attributes to processed data
+	Tags []string `mapstructure:"tags"`
+
+	// HTTPClientSettings configures the proxy, TLS and retry settings of the requests to the Azure IMDS
+	internal.HTTPClientSettings `mapstructure:",squash"`
+}
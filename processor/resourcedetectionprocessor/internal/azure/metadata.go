@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 )
 
 const (
@@ -48,6 +50,21 @@ func NewProvider() Provider {
 	}
 }
 
+// NewProviderFromSettings creates a new metadata provider that uses the given proxy and TLS settings
+func NewProviderFromSettings(settings internal.HTTPClientSettings) (Provider, error) {
+	client, err := settings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return NewProvider(), nil
+	}
+	return &azureProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   client,
+	}, nil
+}
+
 // ComputeMetadata is the Azure IMDS compute metadata response format
 type ComputeMetadata struct {
 	Location          string `json:"location"`
@@ -57,6 +74,15 @@ type ComputeMetadata struct {
 	SubscriptionID    string `json:"subscriptionID"`
 	ResourceGroupName string `json:"resourceGroupName"`
 	VMScaleSetName    string `json:"vmScaleSetName"`
+	FaultDomain       string `json:"platformFaultDomain"`
+	UpdateDomain      string `json:"platformUpdateDomain"`
+	TagsList          []Tag  `json:"tagsList"`
+}
+
+// Tag is a tag of the Azure VM, as returned by the Azure IMDS
+type Tag struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 // Metadata queries a given endpoint and parses the output to the Azure IMDS format
@@ -24,6 +24,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 )
 
 func TestNewProvider(t *testing.T) {
@@ -31,6 +33,15 @@ func TestNewProvider(t *testing.T) {
 	assert.NotNil(t, provider)
 }
 
+func TestNewProviderFromSettings(t *testing.T) {
+	provider, err := NewProviderFromSettings(internal.HTTPClientSettings{ProxyURL: "http://proxy.internal:3128"})
+	require.NoError(t, err)
+	assert.NotNil(t, provider.(*azureProviderImpl).client.Transport)
+
+	_, err = NewProviderFromSettings(internal.HTTPClientSettings{ProxyURL: "://invalid"})
+	assert.Error(t, err)
+}
+
 func TestQueryEndpointFailed(t *testing.T) {
 	ts := httptest.NewServer(http.NotFoundHandler())
 	defer ts.Close()
@@ -67,6 +78,10 @@ func TestQueryEndpointCorrect(t *testing.T) {
 		VMSize:            "vmSize",
 		SubscriptionID:    "subscriptionID",
 		ResourceGroupName: "resourceGroup",
+		VMScaleSetName:    "myScaleset",
+		FaultDomain:       "1",
+		UpdateDomain:      "4",
+		TagsList:          []Tag{{Name: "environment", Value: "prod"}},
 	}
 	marshalledMetadata, err := json.Marshal(sentMetadata)
 	require.NoError(t, err)
@@ -84,5 +99,5 @@ func TestQueryEndpointCorrect(t *testing.T) {
 	recvMetadata, err := provider.Metadata(context.Background())
 
 	require.NoError(t, err)
-	assert.Equal(t, *sentMetadata, *recvMetadata)
+	assert.Equal(t, sentMetadata, recvMetadata)
 }
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+const cacheKey = "detected_resource"
+
+// ResourceCache persists the detected resource to a storage extension, so that it
+// can be reused when detection fails after a restart.
+type ResourceCache struct {
+	id        config.ComponentID
+	storageID *config.ComponentID
+	maxAge    time.Duration
+	client    storage.Client
+}
+
+// cachedResource is the format in which the detected resource is persisted.
+type cachedResource struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Resource is the resource serialized as an OTLP traces request with a single resource.
+	Resource []byte `json:"resource"`
+}
+
+// NewResourceCache creates a cache for the processor with the given ID, persisted to the storage
+// extension with the given ID, or to the only configured storage extension if storageID is nil.
+// Cached resources older than maxAge are not used; a maxAge of zero means that they never expire.
+func NewResourceCache(id config.ComponentID, storageID *config.ComponentID, maxAge time.Duration) *ResourceCache {
+	return &ResourceCache{id: id, storageID: storageID, maxAge: maxAge}
+}
+
+// start looks up the storage extension in host and creates a client for it.
+func (c *ResourceCache) start(ctx context.Context, host component.Host) error {
+	storageExtension, err := c.storageExtension(host)
+	if err != nil {
+		return err
+	}
+
+	client, err := storageExtension.GetClient(ctx, component.KindProcessor, c.id)
+	if err != nil {
+		return err
+	}
+
+	c.client = client
+	return nil
+}
+
+func (c *ResourceCache) storageExtension(host component.Host) (storage.Extension, error) {
+	if c.storageID != nil {
+		ext, ok := host.GetExtensions()[*c.storageID]
+		if !ok {
+			return nil, fmt.Errorf("storage extension %q not found", c.storageID)
+		}
+		storageExtension, ok := ext.(storage.Extension)
+		if !ok {
+			return nil, fmt.Errorf("extension %q is not a storage extension", c.storageID)
+		}
+		return storageExtension, nil
+	}
+
+	var storageExtension storage.Extension
+	for _, ext := range host.GetExtensions() {
+		if se, ok := ext.(storage.Extension); ok {
+			if storageExtension != nil {
+				return nil, errors.New("multiple storage extensions found")
+			}
+			storageExtension = se
+		}
+	}
+
+	if storageExtension == nil {
+		return nil, errors.New("resource cache is enabled, but no storage extension is configured")
+	}
+	return storageExtension, nil
+}
+
+func (c *ResourceCache) store(ctx context.Context, res pdata.Resource) error {
+	td := pdata.NewTraces()
+	res.CopyTo(td.ResourceSpans().AppendEmpty().Resource())
+	resBytes, err := td.ToOtlpProtoBytes()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cachedResource{Timestamp: time.Now(), Resource: resBytes})
+	if err != nil {
+		return err
+	}
+
+	return c.client.Set(ctx, cacheKey, data)
+}
+
+// load returns the cached resource. The returned bool is false if there is no cached
+// resource, or if it is older than the maximum age.
+func (c *ResourceCache) load(ctx context.Context) (pdata.Resource, bool, error) {
+	data, err := c.client.Get(ctx, cacheKey)
+	if err != nil || data == nil {
+		return pdata.NewResource(), false, err
+	}
+
+	var cached cachedResource
+	if err = json.Unmarshal(data, &cached); err != nil {
+		return pdata.NewResource(), false, fmt.Errorf("failed decoding cached resource: %w", err)
+	}
+
+	if c.maxAge > 0 && time.Since(cached.Timestamp) > c.maxAge {
+		return pdata.NewResource(), false, nil
+	}
+
+	td, err := pdata.TracesFromOtlpProtoBytes(cached.Resource)
+	if err != nil {
+		return pdata.NewResource(), false, fmt.Errorf("failed decoding cached resource: %w", err)
+	}
+	if td.ResourceSpans().Len() != 1 {
+		return pdata.NewResource(), false, errors.New("failed decoding cached resource: unexpected number of resources")
+	}
+
+	return td.ResourceSpans().At(0).Resource(), true, nil
+}
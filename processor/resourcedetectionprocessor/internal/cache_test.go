@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "resourcedetection")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestResourceCache_StoreLoad(t *testing.T) {
+	host := storagetest.NewStorageHost(t, newTempDir(t), "test")
+
+	cache := NewResourceCache(config.NewID("resourcedetection"), nil, 0)
+	require.NoError(t, cache.start(context.Background(), host))
+
+	_, ok, err := cache.load(context.Background())
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	res := NewResource(map[string]interface{}{"a": "1", "b": int64(2)})
+	require.NoError(t, cache.store(context.Background(), res))
+
+	got, ok, err := cache.load(context.Background())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"a": "1", "b": int64(2)}, AttributesToMap(got.Attributes()))
+}
+
+func TestResourceCache_MaxAge(t *testing.T) {
+	host := storagetest.NewStorageHost(t, newTempDir(t), "test")
+
+	cache := NewResourceCache(config.NewID("resourcedetection"), nil, time.Nanosecond)
+	require.NoError(t, cache.start(context.Background(), host))
+	require.NoError(t, cache.store(context.Background(), NewResource(map[string]interface{}{"a": "1"})))
+
+	time.Sleep(time.Millisecond)
+
+	_, ok, err := cache.load(context.Background())
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestResourceCache_NoStorageExtension(t *testing.T) {
+	cache := NewResourceCache(config.NewID("resourcedetection"), nil, 0)
+	err := cache.start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, "resource cache is enabled, but no storage extension is configured")
+}
+
+func TestResourceCache_MultipleStorageExtensions(t *testing.T) {
+	host := storagetest.NewStorageHost(t, newTempDir(t), "one", "two")
+
+	cache := NewResourceCache(config.NewID("resourcedetection"), nil, 0)
+	err := cache.start(context.Background(), host)
+	assert.EqualError(t, err, "multiple storage extensions found")
+}
+
+func TestResourceCache_StorageID(t *testing.T) {
+	host := storagetest.NewStorageHost(t, newTempDir(t), "one", "two")
+
+	storageID := config.NewIDWithName("nop", "two")
+	cache := NewResourceCache(config.NewID("resourcedetection"), &storageID, 0)
+	require.NoError(t, cache.start(context.Background(), host))
+	require.NoError(t, cache.store(context.Background(), NewResource(map[string]interface{}{"a": "1"})))
+
+	_, ok, err := cache.load(context.Background())
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestResourceCache_StorageIDNotFound(t *testing.T) {
+	host := storagetest.NewStorageHost(t, newTempDir(t), "one")
+
+	storageID := config.NewIDWithName("nop", "two")
+	cache := NewResourceCache(config.NewID("resourcedetection"), &storageID, 0)
+	err := cache.start(context.Background(), host)
+	assert.EqualError(t, err, `storage extension "nop/two" not found`)
+}
+
+// memoryStorageExtension is a storage extension that shares its data between all clients,
+// which allows simulating a restart within a single test.
+type memoryStorageExtension struct {
+	component.Extension
+	client *memoryClient
+}
+
+func (m *memoryStorageExtension) GetClient(context.Context, component.Kind, config.ComponentID) (storage.Client, error) {
+	return m.client, nil
+}
+
+type memoryClient struct {
+	data map[string][]byte
+}
+
+func (m *memoryClient) Get(_ context.Context, key string) ([]byte, error) {
+	return m.data[key], nil
+}
+
+func (m *memoryClient) Set(_ context.Context, key string, value []byte) error {
+	m.data[key] = value
+	return nil
+}
+
+func (m *memoryClient) Delete(_ context.Context, key string) error {
+	delete(m.data, key)
+	return nil
+}
+
+type memoryStorageHost struct {
+	component.Host
+	extension *memoryStorageExtension
+}
+
+func (h *memoryStorageHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return map[config.ComponentID]component.Extension{config.NewID("memory"): h.extension}
+}
+
+func TestDetectResource_FallbackToCache(t *testing.T) {
+	host := &memoryStorageHost{
+		Host:      componenttest.NewNopHost(),
+		extension: &memoryStorageExtension{client: &memoryClient{data: map[string][]byte{}}},
+	}
+
+	md1 := &MockDetector{}
+	md1.On("Detect").Return(NewResource(map[string]interface{}{"a": "1"}), nil)
+
+	p1 := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second, Cache: NewResourceCache(config.NewID("resourcedetection"), nil, 0)}, requiredDetectors(md1)...)
+	_, err := p1.Get(context.Background(), host)
+	require.NoError(t, err)
+
+	md2 := &MockDetector{}
+	md2.On("Detect").Return(pdata.NewResource(), errors.New("err1"))
+
+	p2 := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second, Cache: NewResourceCache(config.NewID("resourcedetection"), nil, 0)}, requiredDetectors(md2)...)
+	got, err := p2.Get(context.Background(), host)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "1"}, AttributesToMap(got.Attributes()))
+	assert.Equal(t, map[string]string{"a": "cache"}, p2.AttributeSources())
+}
+
+func TestDetectResource_NoCachedResource(t *testing.T) {
+	host := &memoryStorageHost{
+		Host:      componenttest.NewNopHost(),
+		extension: &memoryStorageExtension{client: &memoryClient{data: map[string][]byte{}}},
+	}
+
+	md := &MockDetector{}
+	md.On("Detect").Return(pdata.NewResource(), errors.New("err1"))
+
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second, Cache: NewResourceCache(config.NewID("resourcedetection"), nil, 0)}, requiredDetectors(md)...)
+	_, err := p.Get(context.Background(), host)
+	assert.EqualError(t, err, `failed detecting resource with detector type "mockdetector0": err1`)
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfoundry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "cloudfoundry"
+
+	cloudProviderCloudFoundry = "cloud_foundry"
+
+	attributeOrgID            = "cloudfoundry.org.id"
+	attributeOrgName          = "cloudfoundry.org.name"
+	attributeSpaceID          = "cloudfoundry.space.id"
+	attributeSpaceName        = "cloudfoundry.space.name"
+	attributeAppID            = "cloudfoundry.app.id"
+	attributeAppName          = "cloudfoundry.app.name"
+	attributeAppInstanceIndex = "cloudfoundry.app.instance.index"
+
+	// Environment variable that is set on all Cloud Foundry application instances,
+	// see https://docs.cloudfoundry.org/devguide/deploy-apps/environment-variable.html
+	vcapApplicationEnvVar = "VCAP_APPLICATION"
+	instanceIndexEnvVar   = "CF_INSTANCE_INDEX"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector for Cloud Foundry applications
+type Detector struct{}
+
+// NewDetector returns a resource detector that will detect Cloud Foundry application resources.
+func NewDetector(component.ProcessorCreateParams, internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{}, nil
+}
+
+// vcapApplication is the subset of the VCAP_APPLICATION content used by the detector
+type vcapApplication struct {
+	ApplicationID    string `json:"application_id"`
+	ApplicationName  string `json:"application_name"`
+	OrganizationID   string `json:"organization_id"`
+	OrganizationName string `json:"organization_name"`
+	SpaceID          string `json:"space_id"`
+	SpaceName        string `json:"space_name"`
+	InstanceIndex    *int   `json:"instance_index"`
+}
+
+// Detect returns a Resource describing the Cloud Foundry application being run in.
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	vcap := os.Getenv(vcapApplicationEnvVar)
+	if vcap == "" {
+		return res, nil
+	}
+
+	var app vcapApplication
+	if err := json.Unmarshal([]byte(vcap), &app); err != nil {
+		return res, fmt.Errorf("failed parsing %s: %w", vcapApplicationEnvVar, err)
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, cloudProviderCloudFoundry)
+	insertIfNotEmpty(attr, attributeOrgID, app.OrganizationID)
+	insertIfNotEmpty(attr, attributeOrgName, app.OrganizationName)
+	insertIfNotEmpty(attr, attributeSpaceID, app.SpaceID)
+	insertIfNotEmpty(attr, attributeSpaceName, app.SpaceName)
+	insertIfNotEmpty(attr, attributeAppID, app.ApplicationID)
+	insertIfNotEmpty(attr, attributeAppName, app.ApplicationName)
+
+	// CF_INSTANCE_INDEX is preferred, as newer platforms no longer include the
+	// instance index in VCAP_APPLICATION.
+	if index := os.Getenv(instanceIndexEnvVar); index != "" {
+		i, err := strconv.ParseInt(index, 10, 64)
+		if err != nil {
+			return pdata.NewResource(), fmt.Errorf("failed parsing %s: %w", instanceIndexEnvVar, err)
+		}
+		attr.InsertInt(attributeAppInstanceIndex, i)
+	} else if app.InstanceIndex != nil {
+		attr.InsertInt(attributeAppInstanceIndex, int64(*app.InstanceIndex))
+	}
+
+	return res, nil
+}
+
+func insertIfNotEmpty(attr pdata.AttributeMap, key, value string) {
+	if value != "" {
+		attr.InsertString(key, value)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfoundry
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const vcapApplicationJSON = `{
+	"application_id": "fa05c1a9-0fc1-4fbd-bae1-139850dec7a3",
+	"application_name": "my-app",
+	"application_uris": ["my-app.example.com"],
+	"organization_id": "c0134de6-3ff9-4a0d-9bd2-2b1e1a4d3b5f",
+	"organization_name": "my-org",
+	"space_id": "06450c72-4669-4dc6-8096-45f9777db68a",
+	"space_name": "dev",
+	"instance_index": 0
+}`
+
+func TestNewDetector(t *testing.T) {
+	detector, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, detector)
+}
+
+func TestDetect(t *testing.T) {
+	require.NoError(t, os.Setenv(vcapApplicationEnvVar, vcapApplicationJSON))
+	require.NoError(t, os.Setenv(instanceIndexEnvVar, "2"))
+	defer os.Unsetenv(vcapApplicationEnvVar)
+	defer os.Unsetenv(instanceIndexEnvVar)
+
+	res, err := (&Detector{}).Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":                  "cloud_foundry",
+		"cloudfoundry.org.id":             "c0134de6-3ff9-4a0d-9bd2-2b1e1a4d3b5f",
+		"cloudfoundry.org.name":           "my-org",
+		"cloudfoundry.space.id":           "06450c72-4669-4dc6-8096-45f9777db68a",
+		"cloudfoundry.space.name":         "dev",
+		"cloudfoundry.app.id":             "fa05c1a9-0fc1-4fbd-bae1-139850dec7a3",
+		"cloudfoundry.app.name":           "my-app",
+		"cloudfoundry.app.instance.index": int64(2),
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetect_InstanceIndexFromVCAP(t *testing.T) {
+	require.NoError(t, os.Setenv(vcapApplicationEnvVar, vcapApplicationJSON))
+	require.NoError(t, os.Unsetenv(instanceIndexEnvVar))
+	defer os.Unsetenv(vcapApplicationEnvVar)
+
+	res, err := (&Detector{}).Detect(context.Background())
+	require.NoError(t, err)
+
+	index, ok := res.Attributes().Get(attributeAppInstanceIndex)
+	require.True(t, ok)
+	assert.Equal(t, int64(0), index.IntVal())
+}
+
+func TestDetect_NotCloudFoundry(t *testing.T) {
+	require.NoError(t, os.Unsetenv(vcapApplicationEnvVar))
+
+	res, err := (&Detector{}).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len(), "Resource object should be empty")
+}
+
+func TestDetect_InvalidVCAP(t *testing.T) {
+	require.NoError(t, os.Setenv(vcapApplicationEnvVar, "{"))
+	defer os.Unsetenv(vcapApplicationEnvVar)
+
+	res, err := (&Detector{}).Detect(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
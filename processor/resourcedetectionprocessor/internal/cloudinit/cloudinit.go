@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudinit provides a detector that reads resource information from the instance
+// data cloud-init writes at boot, covering clouds without a dedicated detector.
+package cloudinit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "cloudinit"
+
+	defaultPath = "/run/cloud-init/instance-data.json"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// instanceData is the part of the cloud-init instance data that is common to all datasources.
+type instanceData struct {
+	V1 struct {
+		CloudName        string `json:"cloud_name"`
+		Region           string `json:"region"`
+		AvailabilityZone string `json:"availability_zone"`
+		InstanceID       string `json:"instance_id"`
+		LocalHostname    string `json:"local_hostname"`
+	} `json:"v1"`
+}
+
+// Detector reads resource attributes from the cloud-init instance data
+type Detector struct {
+	path   string
+	logger *zap.Logger
+}
+
+// NewDetector creates a new cloud-init detector
+func NewDetector(params component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	path := cfg.Path
+	if path == "" {
+		path = defaultPath
+	}
+	return &Detector{path: path, logger: params.Logger}, nil
+}
+
+// Detect reads the instance data and returns a resource with the provider, region, zone and
+// instance attributes. An empty resource is returned if the host was not set up by cloud-init.
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	data, err := ioutil.ReadFile(d.path)
+	if os.IsNotExist(err) {
+		d.logger.Debug("cloud-init instance data not available", zap.String("path", d.path))
+		return res, nil
+	}
+	if err != nil {
+		return res, fmt.Errorf("failed reading %s: %w", d.path, err)
+	}
+
+	var instance instanceData
+	if err := json.Unmarshal(data, &instance); err != nil {
+		return res, fmt.Errorf("failed parsing %s: %w", d.path, err)
+	}
+
+	attrs := res.Attributes()
+	insertIfNotEmpty(attrs, conventions.AttributeCloudProvider, cloudProvider(instance.V1.CloudName))
+	insertIfNotEmpty(attrs, conventions.AttributeCloudRegion, instance.V1.Region)
+	insertIfNotEmpty(attrs, conventions.AttributeCloudAvailabilityZone, instance.V1.AvailabilityZone)
+	insertIfNotEmpty(attrs, conventions.AttributeHostID, instance.V1.InstanceID)
+	insertIfNotEmpty(attrs, conventions.AttributeHostName, instance.V1.LocalHostname)
+	return res, nil
+}
+
+// cloudProvider maps the cloud-init cloud name to the cloud.provider convention. The names of
+// clouds without a convention, such as vultr or upcloud, are used as is.
+func cloudProvider(cloudName string) string {
+	switch cloudName {
+	case "gce":
+		return conventions.AttributeCloudProviderGCP
+	case "oracle":
+		return "oracle_cloud"
+	case "ibmcloud":
+		return "ibm_cloud"
+	case "", "unknown", "none", "nocloud":
+		return ""
+	default:
+		return cloudName
+	}
+}
+
+func insertIfNotEmpty(attrs pdata.AttributeMap, key, value string) {
+	if value != "" {
+		attrs.InsertString(key, value)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinit
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
+	require.NoError(t, err)
+	assert.Equal(t, defaultPath, d.(*Detector).path)
+
+	d, err = NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{Path: "testdata/instance-data.json"})
+	require.NoError(t, err)
+	assert.Equal(t, "testdata/instance-data.json", d.(*Detector).path)
+}
+
+func TestDetect(t *testing.T) {
+	d := &Detector{path: filepath.Join("testdata", "instance-data.json"), logger: zap.NewNop()}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider": "vultr",
+		"cloud.region":   "ewr",
+		"host.id":        "4a2b3c4d-0000-1111-2222-333344445555",
+		"host.name":      "vultr-node-1",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectMissingFile(t *testing.T) {
+	d := &Detector{path: filepath.Join("testdata", "missing.json"), logger: zap.NewNop()}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
+
+func TestDetectMalformedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cloudinit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "instance-data.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte("{"), 0600))
+
+	d := &Detector{path: path, logger: zap.NewNop()}
+	res, err := d.Detect(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
+
+func TestCloudProvider(t *testing.T) {
+	for cloudName, expected := range map[string]string{
+		"aws":      "aws",
+		"gce":      "gcp",
+		"oracle":   "oracle_cloud",
+		"ibmcloud": "ibm_cloud",
+		"upcloud":  "upcloud",
+		"nocloud":  "",
+		"unknown":  "",
+		"":         "",
+	} {
+		assert.Equal(t, expected, cloudProvider(cloudName), cloudName)
+	}
+}
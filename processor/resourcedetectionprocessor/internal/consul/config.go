@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+
+// Config defines user-specified configurations unique to the Consul detector
+type Config struct {
+	// Address is the address of the local Consul agent. If empty, it is taken from the
+	// CONSUL_HTTP_ADDR environment variable, and defaults to http://localhost:8500.
+	Address string `mapstructure:"address"`
+
+	// Token is the ACL token used to query the agent. If empty, it is taken from the
+	// CONSUL_HTTP_TOKEN environment variable.
+	Token string `mapstructure:"token"`
+
+	// Meta is the list of node metadata keys that are added as resource attributes.
+	// No node metadata is added if it is empty.
+	Meta []string `mapstructure:"meta"`
+
+	// HTTPClientSettings configures the proxy, TLS and retry settings of the requests to the Consul agent
+	internal.HTTPClientSettings `mapstructure:",squash"`
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is the detector type string
+	TypeStr = "consul"
+
+	metaPrefix = "consul.meta."
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a Consul agent metadata detector
+type Detector struct {
+	provider Provider
+	logger   *zap.Logger
+	metaKeys []string
+}
+
+// NewDetector creates a new Consul agent metadata detector
+func NewDetector(p component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	return &Detector{
+		provider: NewProvider(cfg.Address, cfg.Token, client),
+		logger:   p.Logger,
+		metaKeys: cfg.Meta,
+	}, nil
+}
+
+// Detect detects the Consul node metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	attrs := res.Attributes()
+
+	agent, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("Consul detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return res, nil
+	}
+
+	attrs.InsertString(conventions.AttributeCloudRegion, agent.Config.Datacenter)
+	attrs.InsertString(conventions.AttributeHostName, agent.Config.NodeName)
+	if agent.Config.NodeID != "" {
+		attrs.InsertString(conventions.AttributeHostID, agent.Config.NodeID)
+	}
+
+	for _, key := range d.metaKeys {
+		if value, ok := agent.Meta[key]; ok {
+			attrs.InsertString(metaPrefix+key, value)
+		}
+	}
+
+	return res, nil
+}
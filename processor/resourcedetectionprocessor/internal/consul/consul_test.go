@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockProvider struct {
+	mock.Mock
+}
+
+func (m *mockProvider) Metadata(context.Context) (*AgentMetadata, error) {
+	args := m.MethodCalled("Metadata")
+	arg := args.Get(0)
+	var am *AgentMetadata
+	if arg != nil {
+		am = arg.(*AgentMetadata)
+	}
+	return am, args.Error(1)
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetectConsul(t *testing.T) {
+	agent := &AgentMetadata{Meta: map[string]string{"rack": "r1", "env": "prod", "secret": "s"}}
+	agent.Config.Datacenter = "dc1"
+	agent.Config.NodeName = "node-1"
+	agent.Config.NodeID = "1b4ba8a4-cbbc-4e1f-9b42-7e8e7c7b1b55"
+
+	mp := &mockProvider{}
+	mp.On("Metadata").Return(agent, nil)
+
+	detector := &Detector{provider: mp, logger: zap.NewNop(), metaKeys: []string{"rack", "env", "missing"}}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	mp.AssertExpectations(t)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.region":     "dc1",
+		"host.name":        "node-1",
+		"host.id":          "1b4ba8a4-cbbc-4e1f-9b42-7e8e7c7b1b55",
+		"consul.meta.rack": "r1",
+		"consul.meta.env":  "prod",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectError(t *testing.T) {
+	mp := &mockProvider{}
+	mp.On("Metadata").Return(nil, errors.New("connection refused"))
+
+	detector := &Detector{provider: mp, logger: zap.NewNop()}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
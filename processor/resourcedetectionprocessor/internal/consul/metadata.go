@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	defaultAddress = "http://localhost:8500"
+
+	addressEnvVar = "CONSUL_HTTP_ADDR"
+	tokenEnvVar   = "CONSUL_HTTP_TOKEN"
+	tokenHeader   = "X-Consul-Token"
+
+	// Consul agent endpoint describing the local agent, see https://www.consul.io/api-docs/agent#read-configuration
+	agentSelfPath = "/v1/agent/self"
+)
+
+// Provider gets metadata from the local Consul agent
+type Provider interface {
+	Metadata(context.Context) (*AgentMetadata, error)
+}
+
+type consulProviderImpl struct {
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider for the agent at the given address, using the
+// given HTTP client, or a default client if it is nil
+func NewProvider(address, token string, client *http.Client) Provider {
+	if address == "" {
+		address = os.Getenv(addressEnvVar)
+	}
+	if address == "" {
+		address = defaultAddress
+	}
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	if token == "" {
+		token = os.Getenv(tokenEnvVar)
+	}
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &consulProviderImpl{
+		endpoint: strings.TrimSuffix(address, "/") + agentSelfPath,
+		token:    token,
+		client:   client,
+	}
+}
+
+// AgentMetadata is the subset of the Consul agent self response holding the node metadata
+type AgentMetadata struct {
+	Config struct {
+		Datacenter string `json:"Datacenter"`
+		NodeName   string `json:"NodeName"`
+		NodeID     string `json:"NodeID"`
+	} `json:"Config"`
+	Meta map[string]string `json:"Meta"`
+}
+
+// Metadata queries the Consul agent and parses the output
+func (p *consulProviderImpl) Metadata(ctx context.Context) (*AgentMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	if p.token != "" {
+		req.Header.Set(tokenHeader, p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Consul agent: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		//lint:ignore ST1005 Consul is a capitalized proper noun here
+		return nil, fmt.Errorf("Consul agent replied with status code: %s", resp.Status)
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Consul agent reply: %v", err)
+	}
+
+	var metadata *AgentMetadata
+	if err = json.Unmarshal(respBody, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode Consul agent reply: %v", err)
+	}
+
+	return metadata, nil
+}
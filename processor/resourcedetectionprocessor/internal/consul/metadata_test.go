@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider("", "", nil)
+	assert.Equal(t, "http://localhost:8500/v1/agent/self", provider.(*consulProviderImpl).endpoint)
+
+	provider = NewProvider("consul.service:8500/", "token", nil)
+	assert.Equal(t, "http://consul.service:8500/v1/agent/self", provider.(*consulProviderImpl).endpoint)
+	assert.Equal(t, "token", provider.(*consulProviderImpl).token)
+}
+
+func TestNewProviderFromEnv(t *testing.T) {
+	require.NoError(t, os.Setenv("CONSUL_HTTP_ADDR", "https://127.0.0.1:8501"))
+	require.NoError(t, os.Setenv("CONSUL_HTTP_TOKEN", "env-token"))
+	defer os.Unsetenv("CONSUL_HTTP_ADDR")
+	defer os.Unsetenv("CONSUL_HTTP_TOKEN")
+
+	provider := NewProvider("", "", nil).(*consulProviderImpl)
+	assert.Equal(t, "https://127.0.0.1:8501/v1/agent/self", provider.endpoint)
+	assert.Equal(t, "env-token", provider.token)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	provider := &consulProviderImpl{endpoint: ts.URL, client: &http.Client{}}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "{")
+	}))
+	defer ts.Close()
+
+	provider := &consulProviderImpl{endpoint: ts.URL, client: &http.Client{}}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	var token string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("X-Consul-Token")
+		fmt.Fprint(w, `{
+			"Config": {
+				"Datacenter": "dc1",
+				"NodeName": "node-1",
+				"NodeID": "1b4ba8a4-cbbc-4e1f-9b42-7e8e7c7b1b55",
+				"Server": false
+			},
+			"Meta": {"rack": "r1", "env": "prod"}
+		}`)
+	}))
+	defer ts.Close()
+
+	provider := &consulProviderImpl{endpoint: ts.URL, token: "secret", client: &http.Client{}}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "secret", token)
+	assert.Equal(t, "dc1", metadata.Config.Datacenter)
+	assert.Equal(t, "node-1", metadata.Config.NodeName)
+	assert.Equal(t, "1b4ba8a4-cbbc-4e1f-9b42-7e8e7c7b1b55", metadata.Config.NodeID)
+	assert.Equal(t, map[string]string{"rack": "r1", "env": "prod"}, metadata.Meta)
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// ConversionType is the type a detected attribute value is converted to.
+type ConversionType string
+
+const (
+	// ConvertToInt converts the value to an integer. Doubles are truncated, and booleans become 0 or 1.
+	ConvertToInt ConversionType = "to_int"
+	// ConvertToDouble converts the value to a double. Booleans become 0 or 1.
+	ConvertToDouble ConversionType = "to_double"
+	// ConvertToString converts the value to its string representation.
+	ConvertToString ConversionType = "to_string"
+	// ConvertToBool converts the value to a boolean. Numbers are true if they are not 0.
+	ConvertToBool ConversionType = "to_bool"
+)
+
+// AttributeConversion converts the value of a detected attribute to another type, e.g. for
+// backends that require numeric fields.
+type AttributeConversion struct {
+	// Key is the key of the attribute to convert.
+	Key string `mapstructure:"key"`
+	// Convert is the type to convert the value to, one of "to_int", "to_double", "to_string" or "to_bool".
+	Convert ConversionType `mapstructure:"convert"`
+}
+
+// ValidateAttributeConversions returns an error if any of the conversions is invalid.
+func ValidateAttributeConversions(conversions []AttributeConversion) error {
+	for _, c := range conversions {
+		if c.Key == "" {
+			return fmt.Errorf("attribute conversion %q is missing a key", c.Convert)
+		}
+		switch c.Convert {
+		case ConvertToInt, ConvertToDouble, ConvertToString, ConvertToBool:
+		default:
+			return fmt.Errorf("invalid conversion %q for attribute %q, must be one of %q, %q, %q or %q",
+				c.Convert, c.Key, ConvertToInt, ConvertToDouble, ConvertToString, ConvertToBool)
+		}
+	}
+	return nil
+}
+
+// applyConversions converts the attributes the conversions refer to, in order. Values that
+// cannot be converted, such as a string that is not a number for ConvertToInt, are left
+// unchanged, and an error is returned for each of them.
+func applyConversions(am pdata.AttributeMap, conversions []AttributeConversion) error {
+	var errs []error
+	for _, c := range conversions {
+		v, ok := am.Get(c.Key)
+		if !ok {
+			continue
+		}
+
+		converted, err := convertAttribute(v, c.Convert)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed converting attribute %q %s: %w", c.Key, c.Convert, err))
+			continue
+		}
+		am.Upsert(c.Key, converted)
+	}
+	return consumererror.Combine(errs)
+}
+
+func convertAttribute(v pdata.AttributeValue, to ConversionType) (pdata.AttributeValue, error) {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return convertString(v.StringVal(), to)
+	case pdata.AttributeValueINT:
+		i := v.IntVal()
+		switch to {
+		case ConvertToInt:
+			return pdata.NewAttributeValueInt(i), nil
+		case ConvertToDouble:
+			return pdata.NewAttributeValueDouble(float64(i)), nil
+		case ConvertToString:
+			return pdata.NewAttributeValueString(strconv.FormatInt(i, 10)), nil
+		case ConvertToBool:
+			return pdata.NewAttributeValueBool(i != 0), nil
+		}
+	case pdata.AttributeValueDOUBLE:
+		d := v.DoubleVal()
+		switch to {
+		case ConvertToInt:
+			return pdata.NewAttributeValueInt(int64(d)), nil
+		case ConvertToDouble:
+			return pdata.NewAttributeValueDouble(d), nil
+		case ConvertToString:
+			return pdata.NewAttributeValueString(strconv.FormatFloat(d, 'f', -1, 64)), nil
+		case ConvertToBool:
+			return pdata.NewAttributeValueBool(d != 0), nil
+		}
+	case pdata.AttributeValueBOOL:
+		b := v.BoolVal()
+		switch to {
+		case ConvertToInt:
+			return pdata.NewAttributeValueInt(boolToInt(b)), nil
+		case ConvertToDouble:
+			return pdata.NewAttributeValueDouble(float64(boolToInt(b))), nil
+		case ConvertToString:
+			return pdata.NewAttributeValueString(strconv.FormatBool(b)), nil
+		case ConvertToBool:
+			return pdata.NewAttributeValueBool(b), nil
+		}
+	}
+	return pdata.AttributeValue{}, fmt.Errorf("unsupported value type %v", v.Type())
+}
+
+func convertString(s string, to ConversionType) (pdata.AttributeValue, error) {
+	switch to {
+	case ConvertToInt:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return pdata.AttributeValue{}, err
+		}
+		return pdata.NewAttributeValueInt(i), nil
+	case ConvertToDouble:
+		d, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return pdata.AttributeValue{}, err
+		}
+		return pdata.NewAttributeValueDouble(d), nil
+	case ConvertToBool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return pdata.AttributeValue{}, err
+		}
+		return pdata.NewAttributeValueBool(b), nil
+	default:
+		return pdata.NewAttributeValueString(s), nil
+	}
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
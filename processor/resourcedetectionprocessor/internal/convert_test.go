@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestValidateAttributeConversions(t *testing.T) {
+	assert.NoError(t, ValidateAttributeConversions(nil))
+	assert.NoError(t, ValidateAttributeConversions([]AttributeConversion{
+		{Key: "cloud.account.id", Convert: ConvertToInt},
+		{Key: "host.cpu", Convert: ConvertToDouble},
+		{Key: "host.id", Convert: ConvertToString},
+		{Key: "host.spot", Convert: ConvertToBool},
+	}))
+
+	assert.EqualError(t, ValidateAttributeConversions([]AttributeConversion{{Convert: ConvertToInt}}),
+		`attribute conversion "to_int" is missing a key`)
+	assert.EqualError(t, ValidateAttributeConversions([]AttributeConversion{{Key: "host.id", Convert: "to_bytes"}}),
+		`invalid conversion "to_bytes" for attribute "host.id", must be one of "to_int", "to_double", "to_string" or "to_bool"`)
+}
+
+func TestApplyConversions(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		convert  ConversionType
+		expected interface{}
+	}{
+		{name: "string to int", value: "123456789012", convert: ConvertToInt, expected: int64(123456789012)},
+		{name: "string to double", value: "1.5", convert: ConvertToDouble, expected: 1.5},
+		{name: "string to bool", value: "true", convert: ConvertToBool, expected: true},
+		{name: "string to string", value: "a", convert: ConvertToString, expected: "a"},
+		{name: "int to double", value: int64(2), convert: ConvertToDouble, expected: 2.0},
+		{name: "int to string", value: int64(42), convert: ConvertToString, expected: "42"},
+		{name: "int to bool", value: int64(0), convert: ConvertToBool, expected: false},
+		{name: "double to int", value: 2.9, convert: ConvertToInt, expected: int64(2)},
+		{name: "double to string", value: 0.5, convert: ConvertToString, expected: "0.5"},
+		{name: "double to bool", value: 0.5, convert: ConvertToBool, expected: true},
+		{name: "bool to int", value: true, convert: ConvertToInt, expected: int64(1)},
+		{name: "bool to double", value: false, convert: ConvertToDouble, expected: 0.0},
+		{name: "bool to string", value: true, convert: ConvertToString, expected: "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			am := NewAttributeMap(map[string]interface{}{"key": tt.value})
+			require.NoError(t, applyConversions(am, []AttributeConversion{{Key: "key", Convert: tt.convert}}))
+			assert.Equal(t, map[string]interface{}{"key": tt.expected}, AttributesToMap(am))
+		})
+	}
+}
+
+func TestApplyConversionsError(t *testing.T) {
+	am := NewAttributeMap(map[string]interface{}{
+		"cloud.account.id": "not-a-number",
+		"host.id":          int64(1),
+	})
+	array := pdata.NewAttributeValueArray()
+	array.ArrayVal().AppendEmpty().SetStringVal("a")
+	am.Insert("array", array)
+	err := applyConversions(am, []AttributeConversion{
+		{Key: "cloud.account.id", Convert: ConvertToInt},
+		{Key: "array", Convert: ConvertToString},
+		{Key: "host.id", Convert: ConvertToString},
+		{Key: "missing", Convert: ConvertToInt},
+	})
+	assert.EqualError(t, err, `[failed converting attribute "cloud.account.id" to_int: strconv.ParseInt: parsing "not-a-number": invalid syntax; `+
+		`failed converting attribute "array" to_string: unsupported value type ARRAY]`)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.account.id": "not-a-number",
+		"array":            []interface{}{"a"},
+		"host.id":          "1",
+	}, AttributesToMap(am))
+}
+
+func TestDetectResource_ConvertsAttributes(t *testing.T) {
+	md := &MockDetector{}
+	md.On("Detect").Return(NewResource(map[string]interface{}{"cloud.account.id": "123456789012", "host.name": "my-host"}), nil)
+
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{
+		Timeout:              time.Second,
+		AttributeConversions: []AttributeConversion{{Key: "cloud.account.id", Convert: ConvertToInt}, {Key: "host.name", Convert: ConvertToInt}},
+	}, requiredDetectors(md)...)
+	got, err := p.Get(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"cloud.account.id": int64(123456789012), "host.name": "my-host"}, AttributesToMap(got.Attributes()))
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digitalocean
+
+import (
+	"context"
+	"strconv"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is the detector type string
+	TypeStr = "digitalocean"
+
+	cloudProviderDigitalOcean = "digitalocean"
+	cloudPlatformDroplet      = "digitalocean_droplet"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a DigitalOcean droplet metadata detector
+type Detector struct {
+	provider Provider
+	logger   *zap.Logger
+}
+
+// NewDetector creates a new DigitalOcean droplet metadata detector
+func NewDetector(p component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	return &Detector{provider: NewProvider(client), logger: p.Logger}, nil
+}
+
+// Detect detects droplet metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	attrs := res.Attributes()
+
+	droplet, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("DigitalOcean detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return res, nil
+	}
+
+	attrs.InsertString(conventions.AttributeCloudProvider, cloudProviderDigitalOcean)
+	attrs.InsertString(conventions.AttributeCloudPlatform, cloudPlatformDroplet)
+	attrs.InsertString(conventions.AttributeCloudRegion, droplet.Region)
+	attrs.InsertString(conventions.AttributeHostID, strconv.FormatInt(droplet.DropletID, 10))
+	attrs.InsertString(conventions.AttributeHostName, droplet.Hostname)
+
+	return res, nil
+}
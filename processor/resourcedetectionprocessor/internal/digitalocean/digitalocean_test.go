@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digitalocean
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockProvider struct {
+	mock.Mock
+}
+
+func (m *mockProvider) Metadata(context.Context) (*DropletMetadata, error) {
+	args := m.MethodCalled("Metadata")
+	arg := args.Get(0)
+	var dm *DropletMetadata
+	if arg != nil {
+		dm = arg.(*DropletMetadata)
+	}
+	return dm, args.Error(1)
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetectDigitalOcean(t *testing.T) {
+	mp := &mockProvider{}
+	mp.On("Metadata").Return(&DropletMetadata{DropletID: 2756294, Hostname: "sample-droplet", Region: "nyc3"}, nil)
+
+	detector := &Detector{provider: mp, logger: zap.NewNop()}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	mp.AssertExpectations(t)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider": "digitalocean",
+		"cloud.platform": "digitalocean_droplet",
+		"cloud.region":   "nyc3",
+		"host.id":        "2756294",
+		"host.name":      "sample-droplet",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectError(t *testing.T) {
+	mp := &mockProvider{}
+	mp.On("Metadata").Return(nil, errors.New("connection refused"))
+
+	detector := &Detector{provider: mp, logger: zap.NewNop()}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
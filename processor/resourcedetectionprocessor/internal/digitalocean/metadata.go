@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digitalocean
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const (
+	// DigitalOcean droplet metadata endpoint, see https://docs.digitalocean.com/reference/api/metadata-api/
+	metadataEndpoint = "http://169.254.169.254/metadata/v1.json"
+)
+
+// Provider gets metadata from the DigitalOcean droplet metadata API
+type Provider interface {
+	Metadata(context.Context) (*DropletMetadata, error)
+}
+
+type digitaloceanProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider that uses the given HTTP client,
+// or a default client if it is nil
+func NewProvider(client *http.Client) Provider {
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &digitaloceanProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   client,
+	}
+}
+
+// DropletMetadata is the DigitalOcean droplet metadata response format
+type DropletMetadata struct {
+	DropletID int64    `json:"droplet_id"`
+	Hostname  string   `json:"hostname"`
+	Region    string   `json:"region"`
+	Tags      []string `json:"tags"`
+}
+
+// Metadata queries the droplet metadata endpoint and parses the output
+func (p *digitaloceanProviderImpl) Metadata(ctx context.Context) (*DropletMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query DigitalOcean metadata API: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		//lint:ignore ST1005 DigitalOcean is a capitalized proper noun here
+		return nil, fmt.Errorf("DigitalOcean metadata API replied with status code: %s", resp.Status)
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read DigitalOcean metadata API reply: %v", err)
+	}
+
+	var metadata *DropletMetadata
+	if err = json.Unmarshal(respBody, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode DigitalOcean metadata API reply: %v", err)
+	}
+
+	return metadata, nil
+}
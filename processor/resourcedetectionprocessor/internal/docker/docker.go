@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package docker provides a detector that loads resource information from
+// the Docker daemon of the host the collector is running on.
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "docker"
+
+	defaultEndpoint = "unix:///var/run/docker.sock"
+
+	attributeContainerRuntime = "container.runtime"
+	attributeServerVersion    = "docker.server.version"
+	attributeDefaultRuntime   = "docker.default_runtime"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a Docker host metadata detector
+type Detector struct {
+	provider dockerProvider
+}
+
+// NewDetector creates a new Docker host metadata detector
+func NewDetector(_ component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = defaultEndpoint
+	}
+
+	provider, err := newDockerProvider(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating docker client: %w", err)
+	}
+	return &Detector{provider: provider}, nil
+}
+
+// Detect queries the Docker daemon for information about the host and returns a resource
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	info, err := d.provider.info(ctx)
+	if err != nil {
+		return res, fmt.Errorf("failed getting docker info: %w", err)
+	}
+
+	attrs := res.Attributes()
+	attrs.InsertString(conventions.AttributeHostName, info.Name)
+	attrs.InsertString(conventions.AttributeOSType, strings.ToUpper(info.OSType))
+	if info.OperatingSystem != "" {
+		attrs.InsertString(conventions.AttributeOSDescription, info.OperatingSystem)
+	}
+	attrs.InsertString(attributeContainerRuntime, "docker")
+	attrs.InsertString(attributeServerVersion, info.ServerVersion)
+	if info.DefaultRuntime != "" {
+		attrs.InsertString(attributeDefaultRuntime, info.DefaultRuntime)
+	}
+
+	return res, nil
+}
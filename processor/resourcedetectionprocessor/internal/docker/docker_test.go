@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockDockerProvider struct {
+	mock.Mock
+}
+
+func (m *mockDockerProvider) info(context.Context) (types.Info, error) {
+	args := m.MethodCalled("info")
+	return args.Get(0).(types.Info), args.Error(1)
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	md := &mockDockerProvider{}
+	md.On("info").Return(types.Info{
+		Name:            "docker-host",
+		OSType:          "linux",
+		OperatingSystem: "Ubuntu 20.04.2 LTS",
+		ServerVersion:   "20.10.6",
+		DefaultRuntime:  "runc",
+	}, nil)
+
+	res, err := (&Detector{provider: md}).Detect(context.Background())
+	require.NoError(t, err)
+	md.AssertExpectations(t)
+
+	assert.Equal(t, map[string]interface{}{
+		"host.name":              "docker-host",
+		"os.type":                "LINUX",
+		"os.description":         "Ubuntu 20.04.2 LTS",
+		"container.runtime":      "docker",
+		"docker.server.version":  "20.10.6",
+		"docker.default_runtime": "runc",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectError(t *testing.T) {
+	md := &mockDockerProvider{}
+	md.On("info").Return(types.Info{}, errors.New("connection refused"))
+
+	res, err := (&Detector{provider: md}).Detect(context.Background())
+	assert.EqualError(t, err, "failed getting docker info: connection refused")
+	assert.Equal(t, 0, res.Attributes().Len())
+}
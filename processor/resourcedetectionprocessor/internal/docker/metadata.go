@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+)
+
+type dockerProvider interface {
+	info(ctx context.Context) (types.Info, error)
+}
+
+type dockerProviderImpl struct {
+	client *client.Client
+}
+
+var _ dockerProvider = (*dockerProviderImpl)(nil)
+
+func newDockerProvider(endpoint string) (*dockerProviderImpl, error) {
+	cli, err := client.NewClientWithOpts(client.WithHost(endpoint), client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, err
+	}
+	return &dockerProviderImpl{client: cli}, nil
+}
+
+func (p *dockerProviderImpl) info(ctx context.Context) (types.Info, error) {
+	return p.client.Info(ctx)
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package envfile provides a detector that loads resource information from a file
+// containing one `key=value` pair per line, such as a file written by provisioning
+// tooling or projected by the Kubernetes downward API. Blank lines and lines starting
+// with `#` are ignored, and values may be enclosed in single or double quotes.
+package envfile
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "envfile"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector reads resource attributes from a file
+type Detector struct {
+	path string
+}
+
+// NewDetector creates a new envfile detector
+func NewDetector(_ component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	if cfg.Path == "" {
+		return nil, errors.New("envfile detector requires a path")
+	}
+	return &Detector{path: cfg.Path}, nil
+}
+
+// Detect reads the file and returns a resource with its attributes. The file is read
+// on every call, so changes are picked up when the processor refreshes its resource.
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	data, err := ioutil.ReadFile(d.path)
+	if err != nil {
+		return res, fmt.Errorf("failed reading %s: %w", d.path, err)
+	}
+
+	if err := parseAttributes(res.Attributes(), data); err != nil {
+		res.Attributes().Clear()
+		return res, fmt.Errorf("failed parsing %s: %w", d.path, err)
+	}
+
+	return res, nil
+}
+
+func parseAttributes(am pdata.AttributeMap, data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		idx := strings.Index(line, "=")
+		if idx < 0 {
+			return fmt.Errorf("line %d: expected key=value, got %q", lineNum, line)
+		}
+		key := strings.TrimSpace(line[:idx])
+		if key == "" {
+			return fmt.Errorf("line %d: empty key", lineNum)
+		}
+		am.UpsertString(key, unquote(strings.TrimSpace(line[idx+1:])))
+	}
+	return scanner.Err()
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 {
+		if first, last := value[0], value[len(value)-1]; first == last && (first == '"' || first == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
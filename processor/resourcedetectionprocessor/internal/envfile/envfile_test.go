@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envfile
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{Path: "testdata/resource.env"})
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+
+	d, err = NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
+	assert.EqualError(t, err, "envfile detector requires a path")
+	assert.Nil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	d := &Detector{path: filepath.Join("testdata", "resource.env")}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"deployment.environment": "production",
+		"service.namespace":      "payments",
+		"k8s.pod.labels.app":     "checkout",
+		"empty":                  "",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectRereadsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "envfile")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "resource.env")
+	d := &Detector{path: path}
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("version=1\n"), 0600))
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"version": "1"}, internal.AttributesToMap(res.Attributes()))
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("version=2\n"), 0600))
+	res, err = d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"version": "2"}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "envfile")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	d := &Detector{path: filepath.Join(dir, "missing.env")}
+	res, err := d.Detect(context.Background())
+	assert.Error(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+
+	path := filepath.Join(dir, "invalid.env")
+	require.NoError(t, ioutil.WriteFile(path, []byte("a=b\nnot a pair\n"), 0600))
+	d = &Detector{path: path}
+	res, err = d.Detect(context.Background())
+	assert.EqualError(t, err, "failed parsing "+path+`: line 2: expected key=value, got "not a pair"`)
+	assert.True(t, internal.IsEmptyResource(res))
+}
@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import "time"
+
+// Config defines user-specified configurations unique to the exec detector
+type Config struct {
+	// Command is the program to run followed by its arguments. It must print a JSON
+	// object of resource attributes to stdout.
+	Command []string `mapstructure:"command"`
+
+	// Timeout is the maximum time the command is allowed to run. Defaults to 5s.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
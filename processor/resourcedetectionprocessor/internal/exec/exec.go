@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exec provides a detector that runs a user-specified command and reads
+// resource attributes from the JSON object it prints to stdout.
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "exec"
+
+	defaultTimeout = 5 * time.Second
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector runs a command and returns the resource attributes it prints
+type Detector struct {
+	command []string
+	timeout time.Duration
+}
+
+// NewDetector creates a new exec detector
+func NewDetector(_ component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	if len(cfg.Command) == 0 {
+		return nil, errors.New("exec detector requires a command")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return &Detector{command: cfg.Command, timeout: cfg.Timeout}, nil
+}
+
+// Detect runs the command and converts its output into a resource
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, d.command[0], d.command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return res, fmt.Errorf("command %q timed out after %s", d.command[0], d.timeout)
+		}
+		return res, fmt.Errorf("command %q failed: %w, stderr: %q", d.command[0], err, stderr.String())
+	}
+
+	if err := parseOutput(res.Attributes(), stdout.Bytes()); err != nil {
+		res.Attributes().Clear()
+		return res, fmt.Errorf("invalid output of command %q: %w", d.command[0], err)
+	}
+	return res, nil
+}
+
+func parseOutput(am pdata.AttributeMap, output []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(output))
+	dec.UseNumber()
+
+	var attrs map[string]interface{}
+	if err := dec.Decode(&attrs); err != nil {
+		return err
+	}
+
+	for k, v := range attrs {
+		av, err := toAttributeValue(v)
+		if err != nil {
+			return fmt.Errorf("attribute %q: %w", k, err)
+		}
+		am.Insert(k, av)
+	}
+	return nil
+}
+
+func toAttributeValue(v interface{}) (pdata.AttributeValue, error) {
+	switch t := v.(type) {
+	case string:
+		return pdata.NewAttributeValueString(t), nil
+	case bool:
+		return pdata.NewAttributeValueBool(t), nil
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return pdata.NewAttributeValueInt(i), nil
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return pdata.AttributeValue{}, err
+		}
+		return pdata.NewAttributeValueDouble(f), nil
+	case []interface{}:
+		av := pdata.NewAttributeValueArray()
+		for _, elem := range t {
+			ev, err := toAttributeValue(elem)
+			if err != nil {
+				return pdata.AttributeValue{}, err
+			}
+			av.ArrayVal().Append(ev)
+		}
+		return av, nil
+	default:
+		return pdata.AttributeValue{}, fmt.Errorf("unsupported value %v", v)
+	}
+}
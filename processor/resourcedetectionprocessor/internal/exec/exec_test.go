@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{Command: []string{"metadata.sh"}})
+	require.NoError(t, err)
+	assert.Equal(t, defaultTimeout, d.(*Detector).timeout)
+
+	d, err = NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
+	assert.EqualError(t, err, "exec detector requires a command")
+	assert.Nil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test requires a POSIX shell")
+	}
+
+	tests := []struct {
+		name    string
+		script  string
+		timeout time.Duration
+		want    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:   "success",
+			script: `echo '{"datacenter": "dc1", "rack": 12, "load": 0.5, "primary": true, "roles": ["db", "cache"]}'`,
+			want: map[string]interface{}{
+				"datacenter": "dc1",
+				"rack":       int64(12),
+				"load":       0.5,
+				"primary":    true,
+				"roles":      []interface{}{"db", "cache"},
+			},
+		},
+		{
+			name:    "command fails",
+			script:  `echo oops >&2; exit 1`,
+			wantErr: `command "sh" failed: exit status 1, stderr: "oops\n"`,
+		},
+		{
+			name:    "invalid output",
+			script:  `echo not json`,
+			wantErr: `invalid output of command "sh": invalid character 'o' in literal null (expecting 'u')`,
+		},
+		{
+			name:    "unsupported value",
+			script:  `echo '{"nested": {"a": "b"}}'`,
+			wantErr: `invalid output of command "sh": attribute "nested": unsupported value map[a:b]`,
+		},
+		{
+			name:    "timeout",
+			script:  `exec sleep 5`,
+			timeout: 50 * time.Millisecond,
+			wantErr: `command "sh" timed out after 50ms`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = defaultTimeout
+			}
+			d := &Detector{command: []string{"sh", "-c", tt.script}, timeout: timeout}
+			res, err := d.Detect(context.Background())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.True(t, internal.IsEmptyResource(res))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, internal.AttributesToMap(res.Attributes()))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudfunctions provides a detector that loads resource information from
+// the Cloud Functions environment variables and metadata server
+package cloudfunctions
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "cloudfunctions"
+
+	// Environment variables set by the Cloud Functions runtimes, see
+	// https://cloud.google.com/functions/docs/configuring/env-var#runtime_environment_variables_set_automatically
+	functionTargetEnvVar = "FUNCTION_TARGET"
+	serviceEnvVar        = "K_SERVICE"
+	revisionEnvVar       = "K_REVISION"
+
+	// Environment variables set by the older Node.js 8, Python 3.7 and Go 1.11 runtimes instead
+	functionNameEnvVar    = "FUNCTION_NAME"
+	functionVersionEnvVar = "X_GOOGLE_FUNCTION_VERSION"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	metadata gcp.Metadata
+}
+
+func NewDetector(component.ProcessorCreateParams, internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{metadata: &gcp.MetadataImpl{}}, nil
+}
+
+// Detect detects associated resources when running in Cloud Functions.
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	name := firstEnv(serviceEnvVar, functionNameEnvVar)
+	if name == "" || (os.Getenv(functionTargetEnvVar) == "" && os.Getenv(functionNameEnvVar) == "") {
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderGCP)
+	attr.InsertString(conventions.AttributeCloudPlatform, conventions.AttributeCloudPlatformGCPCloudFunctions)
+	attr.InsertString(conventions.AttributeFaasName, name)
+	if version := firstEnv(revisionEnvVar, functionVersionEnvVar); version != "" {
+		attr.InsertString(conventions.AttributeFaasVersion, version)
+	}
+
+	var errors []error
+
+	projectID, err := d.metadata.ProjectID()
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeCloudAccount, projectID)
+	}
+
+	region, err := gcp.Region(d.metadata)
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeCloudRegion, region)
+	}
+
+	instanceID, err := d.metadata.InstanceID()
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeFaasInstance, instanceID)
+	}
+
+	return res, consumererror.Combine(errors)
+}
+
+// firstEnv returns the value of the first of the given environment variables that is set.
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if val := os.Getenv(name); val != "" {
+			return val
+		}
+	}
+	return ""
+}
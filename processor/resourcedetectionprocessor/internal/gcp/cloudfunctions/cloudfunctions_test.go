@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudfunctions
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
+)
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, nil)
+	assert.NotNil(t, d)
+	assert.NoError(t, err)
+}
+
+func newMockMetadata() *gcp.MockMetadata {
+	md := &gcp.MockMetadata{}
+	md.On("ProjectID").Return("my-project", nil)
+	md.On("Get", "instance/region").Return("projects/123456789/regions/europe-west1", nil)
+	md.On("InstanceID").Return("00bf4bf02d", nil)
+	return md
+}
+
+func TestDetectTrue(t *testing.T) {
+	os.Clearenv()
+	require.NoError(t, os.Setenv(functionTargetEnvVar, "HelloWorld"))
+	require.NoError(t, os.Setenv(serviceEnvVar, "my-function"))
+	require.NoError(t, os.Setenv(revisionEnvVar, "3"))
+
+	md := newMockMetadata()
+	res, err := (&Detector{metadata: md}).Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":   "gcp",
+		"cloud.platform":   "gcp_cloud_functions",
+		"cloud.account.id": "my-project",
+		"cloud.region":     "europe-west1",
+		"faas.name":        "my-function",
+		"faas.version":     "3",
+		"faas.instance":    "00bf4bf02d",
+	}, internal.AttributesToMap(res.Attributes()))
+	md.AssertExpectations(t)
+}
+
+func TestDetectOlderRuntime(t *testing.T) {
+	os.Clearenv()
+	require.NoError(t, os.Setenv(functionNameEnvVar, "my-function"))
+	require.NoError(t, os.Setenv(functionVersionEnvVar, "7"))
+
+	res, err := (&Detector{metadata: newMockMetadata()}).Detect(context.Background())
+	require.NoError(t, err)
+
+	attrs := internal.AttributesToMap(res.Attributes())
+	assert.Equal(t, "my-function", attrs["faas.name"])
+	assert.Equal(t, "7", attrs["faas.version"])
+}
+
+func TestDetectNotCloudFunctions(t *testing.T) {
+	os.Clearenv()
+	// Cloud Run sets K_SERVICE, but not FUNCTION_TARGET
+	require.NoError(t, os.Setenv(serviceEnvVar, "my-service"))
+
+	md := &gcp.MockMetadata{}
+	res, err := (&Detector{metadata: md}).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+	md.AssertExpectations(t)
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudrun provides a detector that loads resource information from
+// the Cloud Run environment variables and metadata server
+package cloudrun
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "cloudrun"
+
+	// Environment variables set in Cloud Run containers, see
+	// https://cloud.google.com/run/docs/reference/container-contract#env-vars
+	serviceEnvVar  = "K_SERVICE"
+	revisionEnvVar = "K_REVISION"
+
+	// Environment variable that is set by the Cloud Functions runtimes, which also
+	// set K_SERVICE and K_REVISION.
+	functionTargetEnvVar = "FUNCTION_TARGET"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	metadata gcp.Metadata
+}
+
+func NewDetector(component.ProcessorCreateParams, internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{metadata: &gcp.MetadataImpl{}}, nil
+}
+
+// Detect detects associated resources when running in Cloud Run.
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	service := os.Getenv(serviceEnvVar)
+	if service == "" || os.Getenv(functionTargetEnvVar) != "" {
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderGCP)
+	attr.InsertString(conventions.AttributeCloudPlatform, conventions.AttributeCloudPlatformGCPCloudRun)
+	attr.InsertString(conventions.AttributeFaasName, service)
+	if revision := os.Getenv(revisionEnvVar); revision != "" {
+		attr.InsertString(conventions.AttributeFaasVersion, revision)
+	}
+
+	var errors []error
+
+	projectID, err := d.metadata.ProjectID()
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeCloudAccount, projectID)
+	}
+
+	region, err := gcp.Region(d.metadata)
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeCloudRegion, region)
+	}
+
+	instanceID, err := d.metadata.InstanceID()
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeFaasInstance, instanceID)
+	}
+
+	return res, consumererror.Combine(errors)
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudrun
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
+)
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, nil)
+	assert.NotNil(t, d)
+	assert.NoError(t, err)
+}
+
+func TestDetectTrue(t *testing.T) {
+	os.Clearenv()
+	require.NoError(t, os.Setenv(serviceEnvVar, "my-service"))
+	require.NoError(t, os.Setenv(revisionEnvVar, "my-service-00001-abc"))
+
+	md := &gcp.MockMetadata{}
+	md.On("ProjectID").Return("my-project", nil)
+	md.On("Get", "instance/region").Return("projects/123456789/regions/us-central1", nil)
+	md.On("InstanceID").Return("00bf4bf02d", nil)
+
+	res, err := (&Detector{metadata: md}).Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":   "gcp",
+		"cloud.platform":   "gcp_cloud_run",
+		"cloud.account.id": "my-project",
+		"cloud.region":     "us-central1",
+		"faas.name":        "my-service",
+		"faas.version":     "my-service-00001-abc",
+		"faas.instance":    "00bf4bf02d",
+	}, internal.AttributesToMap(res.Attributes()))
+	md.AssertExpectations(t)
+}
+
+func TestDetectMetadataError(t *testing.T) {
+	os.Clearenv()
+	require.NoError(t, os.Setenv(serviceEnvVar, "my-service"))
+
+	md := &gcp.MockMetadata{}
+	md.On("ProjectID").Return("", errors.New("err1"))
+	md.On("Get", "instance/region").Return("", errors.New("err2"))
+	md.On("InstanceID").Return("", errors.New("err3"))
+
+	res, err := (&Detector{metadata: md}).Detect(context.Background())
+	assert.EqualError(t, err, "[err1; err2; err3]")
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider": "gcp",
+		"cloud.platform": "gcp_cloud_run",
+		"faas.name":      "my-service",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectNotCloudRun(t *testing.T) {
+	os.Clearenv()
+
+	md := &gcp.MockMetadata{}
+	res, err := (&Detector{metadata: md}).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+
+	// Cloud Functions also set K_SERVICE
+	require.NoError(t, os.Setenv(serviceEnvVar, "my-function"))
+	require.NoError(t, os.Setenv(functionTargetEnvVar, "HelloWorld"))
+	res, err = (&Detector{metadata: md}).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+	md.AssertExpectations(t)
+}
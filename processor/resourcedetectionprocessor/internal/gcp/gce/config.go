@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gce
+
+import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+
+// Config defines user-specified configurations unique to the GCE detector
+type Config struct {
+	// Labels is a list of regex's to match GCE instance label keys that users want
+	// to add as resource attributes to processed data. The labels are read from the
+	// Compute Engine API, which requires the compute.instances.get permission.
+	Labels []string `mapstructure:"labels"`
+
+	// MetadataKeys is a list of custom instance metadata keys whose values are added
+	// as resource attributes to processed data.
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+
+	// HTTPClientSettings configures the proxy, TLS and retry settings of the requests to the GCE metadata server
+	internal.HTTPClientSettings `mapstructure:",squash"`
+}
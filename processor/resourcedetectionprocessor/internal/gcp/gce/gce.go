@@ -18,6 +18,11 @@ package gce
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"regexp"
+
+	"cloud.google.com/go/compute/metadata"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
@@ -30,19 +35,45 @@ import (
 
 const (
 	TypeStr = "gce"
+
+	labelPrefix = "gcp.gce.instance.label."
 )
 
 var _ internal.Detector = (*Detector)(nil)
 
 type Detector struct {
-	metadata gcp.Metadata
+	metadata        gcp.Metadata
+	client          *http.Client
+	computeEndpoint string
+	labelKeyRegexes []*regexp.Regexp
+	metadataKeys    []string
 }
 
-func NewDetector(component.ProcessorCreateParams, internal.DetectorConfig) (internal.Detector, error) {
-	return &Detector{metadata: &gcp.MetadataImpl{}}, nil
+func NewDetector(_ component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	labelKeyRegexes, err := compileRegexes(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// without custom settings, the metadata package's own client and on-GCE check are used
+	md := gcp.NewMetadata(client)
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Detector{
+		metadata:        md,
+		client:          client,
+		computeEndpoint: computeEndpoint,
+		labelKeyRegexes: labelKeyRegexes,
+		metadataKeys:    cfg.MetadataKeys,
+	}, nil
 }
 
-func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
 	res := pdata.NewResource()
 
 	if !d.metadata.OnGCE() {
@@ -54,6 +85,7 @@ func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
 	var errors []error
 	errors = append(errors, d.initializeCloudAttributes(attr)...)
 	errors = append(errors, d.initializeHostAttributes(attr)...)
+	errors = append(errors, d.initializeLabelAttributes(ctx, attr)...)
 	return res, consumererror.Combine(errors)
 }
 
@@ -106,3 +138,32 @@ func (d *Detector) initializeHostAttributes(attr pdata.AttributeMap) []error {
 
 	return errors
 }
+
+func (d *Detector) initializeLabelAttributes(ctx context.Context, attr pdata.AttributeMap) []error {
+	var errs []error
+
+	if len(d.labelKeyRegexes) != 0 {
+		labels, err := d.fetchLabels(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for key, val := range labels {
+			attr.InsertString(labelPrefix+key, val)
+		}
+	}
+
+	for _, key := range d.metadataKeys {
+		val, err := d.metadata.InstanceAttributeValue(key)
+		if err != nil {
+			// Custom metadata keys are only set on some instances, so a missing key is not an error.
+			var notDefined metadata.NotDefinedError
+			if !errors.As(err, &notDefined) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		attr.InsertString(labelPrefix+key, val)
+	}
+
+	return errs
+}
@@ -17,8 +17,12 @@ package gce
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
 	"testing"
 
+	"cloud.google.com/go/compute/metadata"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
@@ -30,11 +34,32 @@ import (
 )
 
 func TestNewDetector(t *testing.T) {
-	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, nil)
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
 	assert.NotNil(t, d)
 	assert.NoError(t, err)
 }
 
+func TestNewDetectorDefaultMetadataClient(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
+	require.NoError(t, err)
+
+	// a metadata without client checks whether it runs on GCE with metadata.OnGCE()
+	assert.Equal(t, gcp.NewMetadata(nil), d.(*Detector).metadata)
+	assert.Equal(t, http.DefaultClient, d.(*Detector).client)
+}
+
+func TestNewDetectorInvalidProxyURL(t *testing.T) {
+	_, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{
+		HTTPClientSettings: internal.HTTPClientSettings{ProxyURL: "://invalid"},
+	})
+	assert.Error(t, err)
+}
+
+func TestNewDetectorInvalidLabelRegex(t *testing.T) {
+	_, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{Labels: []string{"*"}})
+	assert.Error(t, err)
+}
+
 func TestDetectTrue(t *testing.T) {
 	md := &gcp.MockMetadata{}
 	md.On("OnGCE").Return(true)
@@ -101,3 +126,67 @@ func TestDetectError(t *testing.T) {
 	expected.Attributes().Sort()
 	assert.Equal(t, expected, res)
 }
+
+func mockInstanceMetadata() *gcp.MockMetadata {
+	md := &gcp.MockMetadata{}
+	md.On("OnGCE").Return(true)
+	md.On("ProjectID").Return("project", nil)
+	md.On("Zone").Return("us-central1-a", nil)
+	md.On("Hostname").Return("hostname", nil)
+	md.On("InstanceID").Return("2", nil)
+	md.On("InstanceName").Return("instance-1", nil)
+	md.On("Get", "instance/machine-type").Return("machine-type", nil)
+	md.On("Get", "instance/service-accounts/default/token").Return(`{"access_token":"token","expires_in":3599,"token_type":"Bearer"}`, nil)
+	return md
+}
+
+func TestDetectLabels(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/project/zones/us-central1-a/instances/instance-1", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"labels": {"team": "core", "env": "prod", "other": "val"}}`))
+	}))
+	defer ts.Close()
+
+	md := mockInstanceMetadata()
+	md.On("InstanceAttributeValue", "rack").Return("r1", nil)
+	md.On("InstanceAttributeValue", "missing").Return("", metadata.NotDefinedError("instance/attributes/missing"))
+
+	detector := &Detector{
+		metadata:        md,
+		client:          ts.Client(),
+		computeEndpoint: ts.URL,
+		labelKeyRegexes: []*regexp.Regexp{regexp.MustCompile("^team$"), regexp.MustCompile("^env$")},
+		metadataKeys:    []string{"rack", "missing"},
+	}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	attrs := internal.AttributesToMap(res.Attributes())
+	assert.Equal(t, "core", attrs["gcp.gce.instance.label.team"])
+	assert.Equal(t, "prod", attrs["gcp.gce.instance.label.env"])
+	assert.Equal(t, "r1", attrs["gcp.gce.instance.label.rack"])
+	assert.NotContains(t, attrs, "gcp.gce.instance.label.other")
+	assert.NotContains(t, attrs, "gcp.gce.instance.label.missing")
+}
+
+func TestDetectLabelsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	md := mockInstanceMetadata()
+	md.On("InstanceAttributeValue", "rack").Return("", errors.New("err1"))
+
+	detector := &Detector{
+		metadata:        md,
+		client:          ts.Client(),
+		computeEndpoint: ts.URL,
+		labelKeyRegexes: []*regexp.Regexp{regexp.MustCompile(".*")},
+		metadataKeys:    []string{"rack"},
+	}
+	res, err := detector.Detect(context.Background())
+	assert.EqualError(t, err, "[compute API replied with status code: 403 Forbidden; err1]")
+	assert.Equal(t, "hostname", internal.AttributesToMap(res.Attributes())[conventions.AttributeHostName])
+}
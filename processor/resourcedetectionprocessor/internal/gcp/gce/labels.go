@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+const computeEndpoint = "https://compute.googleapis.com/compute/v1"
+
+// fetchLabels reads the labels of the instance from the Compute Engine API, authenticated
+// with the token of the default service account of the instance.
+func (d *Detector) fetchLabels(ctx context.Context) (map[string]string, error) {
+	rawToken, err := d.metadata.Get("instance/service-accounts/default/token")
+	if err != nil {
+		return nil, fmt.Errorf("failed getting service account token: %w", err)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err = json.Unmarshal([]byte(rawToken), &token); err != nil {
+		return nil, fmt.Errorf("failed decoding service account token: %w", err)
+	}
+
+	projectID, err := d.metadata.ProjectID()
+	if err != nil {
+		return nil, err
+	}
+	zone, err := d.metadata.Zone()
+	if err != nil {
+		return nil, err
+	}
+	name, err := d.metadata.InstanceName()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/projects/%s/zones/%s/instances/%s?fields=labels", d.computeEndpoint,
+		url.PathEscape(projectID), url.PathEscape(zone), url.PathEscape(name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("compute API replied with status code: %s", resp.Status)
+	}
+
+	var instance struct {
+		Labels map[string]string `json:"labels"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&instance); err != nil {
+		return nil, fmt.Errorf("failed decoding compute API response: %w", err)
+	}
+
+	labels := make(map[string]string)
+	for key, val := range instance.Labels {
+		if regexArrayMatch(d.labelKeyRegexes, key) {
+			labels[key] = val
+		}
+	}
+	return labels, nil
+}
+
+func compileRegexes(cfg Config) ([]*regexp.Regexp, error) {
+	labelRegexes := make([]*regexp.Regexp, len(cfg.Labels))
+	for i, elem := range cfg.Labels {
+		regex, err := regexp.Compile(elem)
+		if err != nil {
+			return nil, err
+		}
+		labelRegexes[i] = regex
+	}
+	return labelRegexes, nil
+}
+
+func regexArrayMatch(arr []*regexp.Regexp, val string) bool {
+	for _, elem := range arr {
+		if elem.MatchString(val) {
+			return true
+		}
+	}
+	return false
+}
@@ -14,7 +14,12 @@
 
 package gcp
 
-import "cloud.google.com/go/compute/metadata"
+import (
+	"net/http"
+	"strings"
+
+	"cloud.google.com/go/compute/metadata"
+)
 
 type Metadata interface {
 	OnGCE() bool
@@ -27,38 +32,75 @@ type Metadata interface {
 	Get(suffix string) (string, error)
 }
 
-type MetadataImpl struct{}
+// MetadataImpl reads the metadata from the GCE metadata server. The zero value uses the
+// default client of the metadata package.
+type MetadataImpl struct {
+	client *metadata.Client
+}
 
 var _ Metadata = (*MetadataImpl)(nil)
 
+// NewMetadata returns a Metadata that sends its requests with the given HTTP client.
+// A nil client selects the default client of the metadata package.
+func NewMetadata(client *http.Client) *MetadataImpl {
+	if client == nil {
+		return &MetadataImpl{}
+	}
+	return &MetadataImpl{client: metadata.NewClient(client)}
+}
+
+// OnGCE reports whether the metadata server is reachable. With a custom client, this is
+// probed through that client, because the check of the metadata package bypasses proxies.
 func (m *MetadataImpl) OnGCE() bool {
-	return metadata.OnGCE()
+	if m.client == nil {
+		return metadata.OnGCE()
+	}
+	_, err := m.client.ProjectID()
+	return err == nil
+}
+
+func (m *MetadataImpl) metadataClient() *metadata.Client {
+	if m.client == nil {
+		return metadata.NewClient(nil)
+	}
+	return m.client
 }
 
 func (m *MetadataImpl) ProjectID() (string, error) {
-	return metadata.ProjectID()
+	return m.metadataClient().ProjectID()
 }
 
 func (m *MetadataImpl) Zone() (string, error) {
-	return metadata.Zone()
+	return m.metadataClient().Zone()
 }
 
 func (m *MetadataImpl) Hostname() (string, error) {
-	return metadata.Hostname()
+	return m.metadataClient().Hostname()
 }
 
 func (m *MetadataImpl) InstanceAttributeValue(attr string) (string, error) {
-	return metadata.InstanceAttributeValue(attr)
+	return m.metadataClient().InstanceAttributeValue(attr)
 }
 
 func (m *MetadataImpl) InstanceID() (string, error) {
-	return metadata.InstanceID()
+	return m.metadataClient().InstanceID()
 }
 
 func (m *MetadataImpl) InstanceName() (string, error) {
-	return metadata.InstanceName()
+	return m.metadataClient().InstanceName()
 }
 
 func (m *MetadataImpl) Get(suffix string) (string, error) {
-	return metadata.Get(suffix)
+	return m.metadataClient().Get(suffix)
+}
+
+// Region returns the region the instance is running in. The metadata server of
+// serverless environments such as Cloud Run and Cloud Functions reports it as
+// projects/<project number>/regions/<region>.
+func Region(m Metadata) (string, error) {
+	region, err := m.Get("instance/region")
+	if err != nil {
+		return "", err
+	}
+	return region[strings.LastIndex(region, "/")+1:], nil
 }
@@ -15,7 +15,10 @@
 package gcp
 
 import (
+	"net/http"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGCEMetadata(t *testing.T) {
@@ -29,3 +32,8 @@ func TestGCEMetadata(t *testing.T) {
 	metadata.InstanceName()
 	metadata.Get("")
 }
+
+func TestNewMetadata(t *testing.T) {
+	assert.Nil(t, NewMetadata(nil).client)
+	assert.NotNil(t, NewMetadata(&http.Client{}).client)
+}
@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hetzner
+
+import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+
+// Config defines user-specified configurations unique to the Hetzner Cloud detector
+type Config struct {
+	// APIToken is an optional read-only Hetzner Cloud API token. The metadata service does
+	// not report the server type, so host.type is only set when a token is configured.
+	APIToken string `mapstructure:"api_token"`
+
+	// HTTPClientSettings configures the proxy, TLS and retry settings of the requests to the
+	// Hetzner Cloud metadata service and API
+	internal.HTTPClientSettings `mapstructure:",squash"`
+}
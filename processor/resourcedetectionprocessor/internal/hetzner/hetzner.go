@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hetzner
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is the detector type string
+	TypeStr = "hetzner"
+
+	cloudProviderHetzner = "hetzner"
+	cloudPlatformHetzner = "hetzner_cloud_server"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a Hetzner Cloud metadata detector
+type Detector struct {
+	provider      Provider
+	logger        *zap.Logger
+	queryHostType bool
+}
+
+// NewDetector creates a new Hetzner Cloud metadata detector
+func NewDetector(p component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	return &Detector{
+		provider:      NewProvider(cfg.APIToken, client),
+		logger:        p.Logger,
+		queryHostType: cfg.APIToken != "",
+	}, nil
+}
+
+// Detect detects Hetzner Cloud server metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	attrs := res.Attributes()
+
+	server, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("Hetzner Cloud detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return res, nil
+	}
+
+	if d.queryHostType {
+		// The metadata service is reachable, so an API failure is a real error
+		hostType, err := d.provider.ServerType(ctx, server.InstanceID)
+		if err != nil {
+			return res, fmt.Errorf("failed getting server type: %w", err)
+		}
+		attrs.InsertString(conventions.AttributeHostType, hostType)
+	}
+
+	attrs.InsertString(conventions.AttributeCloudProvider, cloudProviderHetzner)
+	attrs.InsertString(conventions.AttributeCloudPlatform, cloudPlatformHetzner)
+	attrs.InsertString(conventions.AttributeCloudRegion, server.Region)
+	attrs.InsertString(conventions.AttributeCloudAvailabilityZone, server.AvailabilityZone)
+	attrs.InsertString(conventions.AttributeHostID, strconv.FormatInt(server.InstanceID, 10))
+	attrs.InsertString(conventions.AttributeHostName, server.Hostname)
+
+	return res, nil
+}
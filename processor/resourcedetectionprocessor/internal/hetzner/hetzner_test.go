@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hetzner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockProvider struct {
+	mock.Mock
+}
+
+func (m *mockProvider) Metadata(context.Context) (*ServerMetadata, error) {
+	args := m.MethodCalled("Metadata")
+	arg := args.Get(0)
+	var sm *ServerMetadata
+	if arg != nil {
+		sm = arg.(*ServerMetadata)
+	}
+	return sm, args.Error(1)
+}
+
+func (m *mockProvider) ServerType(_ context.Context, serverID int64) (string, error) {
+	args := m.MethodCalled("ServerType", serverID)
+	return args.String(0), args.Error(1)
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
+	require.NoError(t, err)
+	assert.False(t, d.(*Detector).queryHostType)
+
+	d, err = NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{APIToken: "token"})
+	require.NoError(t, err)
+	assert.True(t, d.(*Detector).queryHostType)
+}
+
+func TestDetectHetzner(t *testing.T) {
+	metadata := &ServerMetadata{InstanceID: 42, Hostname: "my-server", Region: "eu-central", AvailabilityZone: "fsn1-dc14"}
+	expected := map[string]interface{}{
+		"cloud.provider":          "hetzner",
+		"cloud.platform":          "hetzner_cloud_server",
+		"cloud.region":            "eu-central",
+		"cloud.availability_zone": "fsn1-dc14",
+		"host.id":                 "42",
+		"host.name":               "my-server",
+	}
+
+	t.Run("without API token", func(t *testing.T) {
+		mp := &mockProvider{}
+		mp.On("Metadata").Return(metadata, nil)
+
+		res, err := (&Detector{provider: mp, logger: zap.NewNop()}).Detect(context.Background())
+		require.NoError(t, err)
+		mp.AssertExpectations(t)
+		assert.Equal(t, expected, internal.AttributesToMap(res.Attributes()))
+	})
+
+	t.Run("with API token", func(t *testing.T) {
+		mp := &mockProvider{}
+		mp.On("Metadata").Return(metadata, nil)
+		mp.On("ServerType", int64(42)).Return("cx11", nil)
+
+		res, err := (&Detector{provider: mp, logger: zap.NewNop(), queryHostType: true}).Detect(context.Background())
+		require.NoError(t, err)
+		mp.AssertExpectations(t)
+
+		withType := map[string]interface{}{"host.type": "cx11"}
+		for k, v := range expected {
+			withType[k] = v
+		}
+		assert.Equal(t, withType, internal.AttributesToMap(res.Attributes()))
+	})
+}
+
+func TestDetectError(t *testing.T) {
+	mp := &mockProvider{}
+	mp.On("Metadata").Return(nil, errors.New("connection refused"))
+
+	res, err := (&Detector{provider: mp, logger: zap.NewNop()}).Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+
+	mp = &mockProvider{}
+	mp.On("Metadata").Return(&ServerMetadata{InstanceID: 42}, nil)
+	mp.On("ServerType", int64(42)).Return("", errors.New("unauthorized"))
+
+	res, err = (&Detector{provider: mp, logger: zap.NewNop(), queryHostType: true}).Detect(context.Background())
+	assert.EqualError(t, err, "failed getting server type: unauthorized")
+	assert.True(t, internal.IsEmptyResource(res))
+}
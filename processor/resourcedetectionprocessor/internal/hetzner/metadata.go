@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hetzner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Hetzner Cloud metadata endpoint, see https://docs.hetzner.cloud/#server-metadata
+	metadataEndpoint = "http://169.254.169.254/hetzner/v1/metadata"
+	// Hetzner Cloud API endpoint, see https://docs.hetzner.cloud/#servers-get-a-server
+	apiEndpoint = "https://api.hetzner.cloud/v1/servers/"
+)
+
+// Provider gets metadata from the Hetzner Cloud metadata service and API
+type Provider interface {
+	Metadata(context.Context) (*ServerMetadata, error)
+	ServerType(ctx context.Context, serverID int64) (string, error)
+}
+
+type hetznerProviderImpl struct {
+	metadataEndpoint string
+	apiEndpoint      string
+	apiToken         string
+	client           *http.Client
+}
+
+// NewProvider creates a new metadata provider that uses the given HTTP client,
+// or a default client if it is nil
+func NewProvider(apiToken string, client *http.Client) Provider {
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &hetznerProviderImpl{
+		metadataEndpoint: metadataEndpoint,
+		apiEndpoint:      apiEndpoint,
+		apiToken:         apiToken,
+		client:           client,
+	}
+}
+
+// ServerMetadata is the Hetzner Cloud metadata response format
+type ServerMetadata struct {
+	InstanceID       int64  `yaml:"instance-id"`
+	Hostname         string `yaml:"hostname"`
+	Region           string `yaml:"region"`
+	AvailabilityZone string `yaml:"availability-zone"`
+}
+
+// Metadata queries the metadata endpoint and parses the output
+func (p *hetznerProviderImpl) Metadata(ctx context.Context) (*ServerMetadata, error) {
+	body, err := p.get(ctx, p.metadataEndpoint, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata *ServerMetadata
+	if err = yaml.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode Hetzner Cloud metadata reply: %v", err)
+	}
+	return metadata, nil
+}
+
+// ServerType queries the Hetzner Cloud API for the type of the given server
+func (p *hetznerProviderImpl) ServerType(ctx context.Context, serverID int64) (string, error) {
+	body, err := p.get(ctx, p.apiEndpoint+strconv.FormatInt(serverID, 10), p.apiToken)
+	if err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		Server struct {
+			ServerType struct {
+				Name string `json:"name"`
+			} `json:"server_type"`
+		} `json:"server"`
+	}
+	if err = json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("failed to decode Hetzner Cloud API reply: %v", err)
+	}
+	return resp.Server.ServerType.Name, nil
+}
+
+func (p *hetznerProviderImpl) get(ctx context.Context, url string, token string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	if token != "" {
+		req.Header.Add("Authorization", "Bearer "+token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s replied with status code: %s", url, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reply of %s: %v", url, err)
+	}
+	return body, nil
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hetzner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider("", nil)
+	assert.NotNil(t, provider)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	provider := &hetznerProviderImpl{metadataEndpoint: ts.URL, apiEndpoint: ts.URL + "/", client: &http.Client{}}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+
+	_, err = provider.ServerType(context.Background(), 42)
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "{")
+	}))
+	defer ts.Close()
+
+	provider := &hetznerProviderImpl{metadataEndpoint: ts.URL, apiEndpoint: ts.URL + "/", client: &http.Client{}}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+
+	_, err = provider.ServerType(context.Background(), 42)
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metadata", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "availability-zone: fsn1-dc14\nhostname: my-server\ninstance-id: 42\npublic-ipv4: 1.2.3.4\nregion: eu-central\n")
+	})
+	mux.HandleFunc("/servers/42", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"server": {"id": 42, "server_type": {"name": "cx11"}}}`)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	provider := &hetznerProviderImpl{metadataEndpoint: ts.URL + "/metadata", apiEndpoint: ts.URL + "/servers/", apiToken: "token", client: &http.Client{}}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &ServerMetadata{
+		InstanceID:       42,
+		Hostname:         "my-server",
+		Region:           "eu-central",
+		AvailabilityZone: "fsn1-dc14",
+	}, metadata)
+
+	serverType, err := provider.ServerType(context.Background(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, "cx11", serverType)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// HTTPClientSettings configures the HTTP client used by detectors that query a metadata endpoint.
+type HTTPClientSettings struct {
+	// ProxyURL is the URL of the proxy requests to the metadata endpoint are sent through.
+	// If empty, the proxy is taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string `mapstructure:"proxy_url"`
+
+	// TLSSetting configures TLS for connections to the metadata endpoint, or to a proxy or
+	// gateway in front of it.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// Retry configures retrying requests that fail, e.g. because the network is not ready yet at boot.
+	Retry RetrySettings `mapstructure:"retry"`
+}
+
+// ToClient creates an HTTP client from the settings. It returns a nil client if the
+// settings are empty, in which case the detector should use its default client.
+func (s HTTPClientSettings) ToClient() (*http.Client, error) {
+	if s == (HTTPClientSettings{}) {
+		return nil, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if s.ProxyURL != "" {
+		proxyURL, err := url.Parse(s.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", s.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsCfg, err := s.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsCfg
+
+	if s.Retry.MaxAttempts > 1 {
+		return &http.Client{Transport: &retryTransport{next: transport, settings: s.Retry}}, nil
+	}
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+func TestHTTPClientSettings_Empty(t *testing.T) {
+	client, err := HTTPClientSettings{}.ToClient()
+	require.NoError(t, err)
+	assert.Nil(t, client)
+}
+
+func TestHTTPClientSettings_ProxyURL(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte("ok"))
+	}))
+	defer proxy.Close()
+
+	client, err := HTTPClientSettings{ProxyURL: proxy.URL}.ToClient()
+	require.NoError(t, err)
+
+	resp, err := client.Get("http://169.254.169.254/latest/meta-data")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "http://169.254.169.254/latest/meta-data", proxied)
+}
+
+func TestHTTPClientSettings_InvalidProxyURL(t *testing.T) {
+	_, err := HTTPClientSettings{ProxyURL: "://invalid"}.ToClient()
+	assert.Error(t, err)
+}
+
+func TestHTTPClientSettings_TLS(t *testing.T) {
+	client, err := HTTPClientSettings{TLSSetting: configtls.TLSClientSetting{
+		InsecureSkipVerify: true,
+		ServerName:         "metadata.internal",
+	}}.ToClient()
+	require.NoError(t, err)
+
+	transport := client.Transport.(*http.Transport)
+	assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+	assert.Equal(t, "metadata.internal", transport.TLSClientConfig.ServerName)
+
+	// without an explicit proxy URL, the proxy is taken from the environment
+	req := &http.Request{URL: &url.URL{Scheme: "http", Host: "169.254.169.254"}}
+	_, err = transport.Proxy(req)
+	assert.NoError(t, err)
+
+	_, err = HTTPClientSettings{TLSSetting: configtls.TLSClientSetting{
+		TLSSetting: configtls.TLSSetting{CAFile: "does-not-exist.pem"},
+	}}.ToClient()
+	assert.Error(t, err)
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpendpoint
+
+import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+
+// Config defines user-specified configurations unique to the HTTP endpoint detector
+type Config struct {
+	// Endpoint is the URL that is queried with a GET request. It must reply with a JSON
+	// object of resource attributes.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Headers are added to the request, e.g. an Authorization header.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// HTTPClientSettings configures the proxy, TLS and retry settings of the requests to the endpoint.
+	// Client certificates can be configured for endpoints that require mutual TLS.
+	internal.HTTPClientSettings `mapstructure:",squash"`
+}
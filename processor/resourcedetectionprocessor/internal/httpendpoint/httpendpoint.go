@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpendpoint provides a detector that reads resource attributes from the JSON
+// object served by a user-specified HTTP endpoint, such as an internal asset inventory.
+package httpendpoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "http"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector queries an HTTP endpoint and returns the resource attributes it serves
+type Detector struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+// NewDetector creates a new HTTP endpoint detector
+func NewDetector(_ component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	if cfg.Endpoint == "" {
+		return nil, errors.New("http detector requires an endpoint")
+	}
+
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &Detector{endpoint: cfg.Endpoint, headers: cfg.Headers, client: client}, nil
+}
+
+// Detect queries the endpoint and converts its reply into a resource
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.endpoint, nil)
+	if err != nil {
+		return res, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range d.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return res, fmt.Errorf("failed to query %q: %w", d.endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return res, fmt.Errorf("%q replied with status code: %s", d.endpoint, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return res, fmt.Errorf("failed to read reply of %q: %w", d.endpoint, err)
+	}
+
+	if err := parseBody(res.Attributes(), body); err != nil {
+		res.Attributes().Clear()
+		return res, fmt.Errorf("invalid reply of %q: %w", d.endpoint, err)
+	}
+	return res, nil
+}
+
+func parseBody(am pdata.AttributeMap, body []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var attrs map[string]interface{}
+	if err := dec.Decode(&attrs); err != nil {
+		return err
+	}
+
+	for k, v := range attrs {
+		// inventories commonly reply null for unset fields, which have no attribute value
+		if v == nil {
+			continue
+		}
+		av, err := toAttributeValue(v)
+		if err != nil {
+			return fmt.Errorf("attribute %q: %w", k, err)
+		}
+		am.Insert(k, av)
+	}
+	return nil
+}
+
+func toAttributeValue(v interface{}) (pdata.AttributeValue, error) {
+	switch t := v.(type) {
+	case string:
+		return pdata.NewAttributeValueString(t), nil
+	case bool:
+		return pdata.NewAttributeValueBool(t), nil
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return pdata.NewAttributeValueInt(i), nil
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return pdata.AttributeValue{}, err
+		}
+		return pdata.NewAttributeValueDouble(f), nil
+	case []interface{}:
+		av := pdata.NewAttributeValueArray()
+		for _, elem := range t {
+			if elem == nil {
+				continue
+			}
+			ev, err := toAttributeValue(elem)
+			if err != nil {
+				return pdata.AttributeValue{}, err
+			}
+			av.ArrayVal().Append(ev)
+		}
+		return av, nil
+	default:
+		return pdata.AttributeValue{}, fmt.Errorf("unsupported value %v", v)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpendpoint
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{Endpoint: "http://cmdb.internal/host"})
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+
+	d, err = NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
+	assert.EqualError(t, err, "http detector requires an endpoint")
+	assert.Nil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			body:   `{"datacenter": "dc1", "rack": 12, "load": 0.5, "primary": true, "roles": ["db", "cache"]}`,
+			want: map[string]interface{}{
+				"datacenter": "dc1",
+				"rack":       int64(12),
+				"load":       0.5,
+				"primary":    true,
+				"roles":      []interface{}{"db", "cache"},
+			},
+		},
+		{
+			name:   "null values",
+			status: http.StatusOK,
+			body:   `{"datacenter": "dc1", "owner": null, "roles": ["db", null]}`,
+			want: map[string]interface{}{
+				"datacenter": "dc1",
+				"roles":      []interface{}{"db"},
+			},
+		},
+		{
+			name:    "error status",
+			status:  http.StatusUnauthorized,
+			wantErr: "replied with status code: 401 Unauthorized",
+		},
+		{
+			name:    "invalid reply",
+			status:  http.StatusOK,
+			body:    `not json`,
+			wantErr: "invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			name:    "unsupported value",
+			status:  http.StatusOK,
+			body:    `{"nested": {"a": "b"}}`,
+			wantErr: `attribute "nested": unsupported value map[a:b]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer ts.Close()
+
+			d := &Detector{endpoint: ts.URL, headers: map[string]string{"authorization": "Bearer token"}, client: ts.Client()}
+			res, err := d.Detect(context.Background())
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.True(t, internal.IsEmptyResource(res))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, internal.AttributesToMap(res.Attributes()))
+		})
+	}
+}
+
+func TestDetectUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+
+	d := &Detector{endpoint: ts.URL, client: &http.Client{}}
+	_, err := d.Detect(context.Background())
+	assert.Error(t, err)
+}
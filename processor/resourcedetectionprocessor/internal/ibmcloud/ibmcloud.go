@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ibmcloud
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is the detector type string
+	TypeStr = "ibmcloud"
+
+	cloudProviderIBM    = "ibm_cloud"
+	cloudPlatformIBMVPC = "ibm_cloud_vpc"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is an IBM Cloud VPC metadata detector
+type Detector struct {
+	provider Provider
+	logger   *zap.Logger
+}
+
+// NewDetector creates a new IBM Cloud VPC metadata detector
+func NewDetector(p component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	return &Detector{provider: NewProvider(client), logger: p.Logger}, nil
+}
+
+// Detect detects IBM Cloud VPC instance metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	attrs := res.Attributes()
+
+	instance, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("IBM Cloud detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return res, nil
+	}
+
+	attrs.InsertString(conventions.AttributeCloudProvider, cloudProviderIBM)
+	attrs.InsertString(conventions.AttributeCloudPlatform, cloudPlatformIBMVPC)
+	attrs.InsertString(conventions.AttributeCloudRegion, zoneToRegion(instance.Zone.Name))
+	attrs.InsertString(conventions.AttributeCloudAvailabilityZone, instance.Zone.Name)
+	if account := accountFromCRN(instance.CRN); account != "" {
+		attrs.InsertString(conventions.AttributeCloudAccount, account)
+	}
+	attrs.InsertString(conventions.AttributeHostID, instance.ID)
+	attrs.InsertString(conventions.AttributeHostName, instance.Name)
+	attrs.InsertString(conventions.AttributeHostType, instance.Profile.Name)
+	if instance.Image.ID != "" {
+		attrs.InsertString(conventions.AttributeHostImageID, instance.Image.ID)
+	}
+
+	return res, nil
+}
+
+// zoneToRegion strips the zone number from a zone name, e.g. us-south-1 becomes us-south.
+func zoneToRegion(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		return zone[:i]
+	}
+	return zone
+}
+
+// accountFromCRN returns the account ID from the scope of a CRN, which has the
+// format crn:v1:bluemix:public:is:us-south-1:a/<account>::instance:<id>.
+func accountFromCRN(crn string) string {
+	parts := strings.Split(crn, ":")
+	if len(parts) < 7 || !strings.HasPrefix(parts[6], "a/") {
+		return ""
+	}
+	return strings.TrimPrefix(parts[6], "a/")
+}
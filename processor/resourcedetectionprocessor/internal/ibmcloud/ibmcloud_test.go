@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ibmcloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockProvider struct {
+	mock.Mock
+}
+
+func (m *mockProvider) Metadata(context.Context) (*InstanceMetadata, error) {
+	args := m.MethodCalled("Metadata")
+	arg := args.Get(0)
+	var im *InstanceMetadata
+	if arg != nil {
+		im = arg.(*InstanceMetadata)
+	}
+	return im, args.Error(1)
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetectIBMCloud(t *testing.T) {
+	mp := &mockProvider{}
+	mp.On("Metadata").Return(&InstanceMetadata{
+		ID:      "0717_1e09281b",
+		Name:    "my-instance",
+		CRN:     "crn:v1:bluemix:public:is:us-south-1:a/123456::instance:0717_1e09281b",
+		Profile: reference{Name: "bx2-2x8"},
+		Zone:    reference{Name: "us-south-1"},
+		Image:   reference{ID: "r006-02c73baf"},
+	}, nil)
+
+	detector := &Detector{provider: mp, logger: zap.NewNop()}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	mp.AssertExpectations(t)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":          "ibm_cloud",
+		"cloud.platform":          "ibm_cloud_vpc",
+		"cloud.region":            "us-south",
+		"cloud.availability_zone": "us-south-1",
+		"cloud.account.id":        "123456",
+		"host.id":                 "0717_1e09281b",
+		"host.name":               "my-instance",
+		"host.type":               "bx2-2x8",
+		"host.image.id":           "r006-02c73baf",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectError(t *testing.T) {
+	mp := &mockProvider{}
+	mp.On("Metadata").Return(nil, errors.New("connection refused"))
+
+	detector := &Detector{provider: mp, logger: zap.NewNop()}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestAccountFromCRN(t *testing.T) {
+	assert.Equal(t, "123456", accountFromCRN("crn:v1:bluemix:public:is:us-south-1:a/123456::instance:id"))
+	assert.Equal(t, "", accountFromCRN("crn:v1:bluemix:public:is:us-south-1:o/123456::instance:id"))
+	assert.Equal(t, "", accountFromCRN("invalid"))
+}
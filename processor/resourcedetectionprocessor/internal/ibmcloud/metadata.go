@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ibmcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const (
+	// IBM Cloud VPC metadata service endpoint, see https://cloud.ibm.com/docs/vpc?topic=vpc-imd-about
+	metadataEndpoint = "http://169.254.169.254"
+
+	apiVersion   = "2022-03-01"
+	tokenPath    = "/instance_identity/v1/token"
+	instancePath = "/metadata/v1/instance"
+)
+
+// Provider gets metadata from the IBM Cloud VPC metadata service
+type Provider interface {
+	Metadata(context.Context) (*InstanceMetadata, error)
+}
+
+type ibmcloudProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider that uses the given HTTP client,
+// or a default client if it is nil
+func NewProvider(client *http.Client) Provider {
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &ibmcloudProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   client,
+	}
+}
+
+// InstanceMetadata is the IBM Cloud VPC instance metadata response format
+type InstanceMetadata struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	CRN     string    `json:"crn"`
+	Profile reference `json:"profile"`
+	Zone    reference `json:"zone"`
+	Image   reference `json:"image"`
+}
+
+type reference struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Metadata gets an instance identity token and uses it to query the instance metadata
+func (p *ibmcloudProviderImpl) Metadata(ctx context.Context) (*InstanceMetadata, error) {
+	token, err := p.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+instancePath+"?version="+apiVersion, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	var metadata *InstanceMetadata
+	if err := p.do(req, &metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+func (p *ibmcloudProviderImpl) token(ctx context.Context) (string, error) {
+	body := bytes.NewBufferString(`{"expires_in": 300}`)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, p.endpoint+tokenPath+"?version="+apiVersion, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %v", err)
+	}
+	req.Header.Add("Metadata-Flavor", "ibm")
+	req.Header.Add("Content-Type", "application/json")
+
+	var resp struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := p.do(req, &resp); err != nil {
+		return "", err
+	}
+	return resp.AccessToken, nil
+}
+
+func (p *ibmcloudProviderImpl) do(req *http.Request, v interface{}) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query IBM Cloud metadata service: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		//lint:ignore ST1005 IBM Cloud is a capitalized proper noun here
+		return fmt.Errorf("IBM Cloud metadata service replied with status code: %s", resp.Status)
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read IBM Cloud metadata service reply: %v", err)
+	}
+	if err := json.Unmarshal(respBody, v); err != nil {
+		return fmt.Errorf("failed to decode IBM Cloud metadata service reply: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ibmcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider(nil)
+	assert.NotNil(t, provider)
+}
+
+func newMetadataServer(t *testing.T, instance string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(tokenPath, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "ibm", r.Header.Get("Metadata-Flavor"))
+		fmt.Fprint(w, `{"access_token": "token"}`)
+	})
+	mux.HandleFunc(instancePath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, instance)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	provider := &ibmcloudProviderImpl{endpoint: ts.URL, client: &http.Client{}}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := newMetadataServer(t, "{")
+	defer ts.Close()
+
+	provider := &ibmcloudProviderImpl{endpoint: ts.URL, client: &http.Client{}}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := newMetadataServer(t, `{
+		"id": "0717_1e09281b-f177-46fb-baf1-bc152b2e391a",
+		"name": "my-instance",
+		"crn": "crn:v1:bluemix:public:is:us-south-1:a/123456::instance:0717_1e09281b-f177-46fb-baf1-bc152b2e391a",
+		"profile": {"name": "bx2-2x8"},
+		"zone": {"name": "us-south-1"},
+		"image": {"id": "r006-02c73baf-9abb-493d-9e41-d0f1866f4051", "name": "ibm-ubuntu-20-04"}
+	}`)
+	defer ts.Close()
+
+	provider := &ibmcloudProviderImpl{endpoint: ts.URL, client: &http.Client{}}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &InstanceMetadata{
+		ID:      "0717_1e09281b-f177-46fb-baf1-bc152b2e391a",
+		Name:    "my-instance",
+		CRN:     "crn:v1:bluemix:public:is:us-south-1:a/123456::instance:0717_1e09281b-f177-46fb-baf1-bc152b2e391a",
+		Profile: reference{Name: "bx2-2x8"},
+		Zone:    reference{Name: "us-south-1"},
+		Image:   reference{ID: "r006-02c73baf-9abb-493d-9e41-d0f1866f4051", Name: "ibm-ubuntu-20-04"},
+	}, metadata)
+}
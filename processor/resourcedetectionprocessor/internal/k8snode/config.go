@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8snode
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+// Config defines user-specified configurations unique to the Kubernetes node detector
+type Config struct {
+	k8sconfig.APIConfig `mapstructure:",squash"`
+
+	// NodeFromEnvVar is the name of the environment variable that holds the name of
+	// the node the collector runs on, usually populated through the downward API.
+	// Defaults to K8S_NODE_NAME.
+	NodeFromEnvVar string `mapstructure:"node_from_env_var"`
+
+	// Labels is a list of node label keys that should be added as resource
+	// attributes in the form k8s.node.labels.<key>.
+	Labels []string `mapstructure:"labels"`
+}
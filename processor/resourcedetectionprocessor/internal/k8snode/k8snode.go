@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8snode
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "k8snode"
+
+	defaultNodeFromEnvVar = "K8S_NODE_NAME"
+
+	attributeKernelVersion = "k8s.node.kernel.version"
+	labelPrefix            = "k8s.node.labels."
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a Kubernetes node metadata detector
+type Detector struct {
+	client         kubernetes.Interface
+	nodeFromEnvVar string
+	labels         []string
+}
+
+// NewDetector returns a resource detector that queries the Kubernetes API for
+// the node the collector is running on.
+func NewDetector(_ component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	if cfg.AuthType == "" {
+		cfg.AuthType = k8sconfig.AuthTypeServiceAccount
+	}
+	if cfg.NodeFromEnvVar == "" {
+		cfg.NodeFromEnvVar = defaultNodeFromEnvVar
+	}
+
+	client, err := k8sconfig.MakeClient(cfg.APIConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Detector{client: client, nodeFromEnvVar: cfg.NodeFromEnvVar, labels: cfg.Labels}, nil
+}
+
+// Detect returns a Resource describing the Kubernetes node the collector is running on.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	nodeName := os.Getenv(d.nodeFromEnvVar)
+	if nodeName == "" {
+		return res, fmt.Errorf("node name not found in environment variable %q", d.nodeFromEnvVar)
+	}
+
+	node, err := d.client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return res, fmt.Errorf("failed getting node %q: %w", nodeName, err)
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeK8sNodeName, node.Name)
+	attr.InsertString(conventions.AttributeK8sNodeUID, string(node.UID))
+
+	nodeInfo := node.Status.NodeInfo
+	if nodeInfo.OperatingSystem != "" {
+		attr.InsertString(conventions.AttributeOSType, strings.ToUpper(nodeInfo.OperatingSystem))
+	}
+	if nodeInfo.OSImage != "" {
+		attr.InsertString(conventions.AttributeOSDescription, nodeInfo.OSImage)
+	}
+	if nodeInfo.KernelVersion != "" {
+		attr.InsertString(attributeKernelVersion, nodeInfo.KernelVersion)
+	}
+
+	for _, key := range d.labels {
+		if val, ok := node.Labels[key]; ok {
+			attr.InsertString(labelPrefix+key, val)
+		}
+	}
+
+	return res, nil
+}
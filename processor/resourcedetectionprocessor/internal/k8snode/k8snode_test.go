@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8snode
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+func newNode() *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+			UID:  "3f8a6c2e-uid",
+			Labels: map[string]string{
+				"topology.kubernetes.io/zone": "us-west-2a",
+				"kubernetes.io/arch":          "amd64",
+			},
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{
+				OperatingSystem: "linux",
+				OSImage:         "Ubuntu 20.04.2 LTS",
+				KernelVersion:   "5.4.0-1045-aws",
+			},
+		},
+	}
+}
+
+func TestDetect(t *testing.T) {
+	os.Setenv(defaultNodeFromEnvVar, "node1")
+	defer os.Unsetenv(defaultNodeFromEnvVar)
+
+	d := &Detector{
+		client:         fake.NewSimpleClientset(newNode()),
+		nodeFromEnvVar: defaultNodeFromEnvVar,
+		labels:         []string{"topology.kubernetes.io/zone", "missing"},
+	}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"k8s.node.name":           "node1",
+		"k8s.node.uid":            "3f8a6c2e-uid",
+		"os.type":                 "LINUX",
+		"os.description":          "Ubuntu 20.04.2 LTS",
+		"k8s.node.kernel.version": "5.4.0-1045-aws",
+		"k8s.node.labels.topology.kubernetes.io/zone": "us-west-2a",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetect_NodeNameNotSet(t *testing.T) {
+	os.Unsetenv(defaultNodeFromEnvVar)
+
+	d := &Detector{client: fake.NewSimpleClientset(newNode()), nodeFromEnvVar: defaultNodeFromEnvVar}
+	res, err := d.Detect(context.Background())
+	assert.EqualError(t, err, `node name not found in environment variable "K8S_NODE_NAME"`)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
+
+func TestDetect_NodeNotFound(t *testing.T) {
+	os.Setenv("NODE", "node2")
+	defer os.Unsetenv("NODE")
+
+	d := &Detector{client: fake.NewSimpleClientset(newNode()), nodeFromEnvVar: "NODE"}
+	_, err := d.Detect(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var (
+	tagDetectorKey = tag.MustNewKey("detector")
+	tagResultKey   = tag.MustNewKey("result")
+
+	mDetectorRuns       = stats.Int64("processor_resourcedetection_detector_runs", "Number of detector runs, by result", stats.UnitDimensionless)
+	mDetectorDuration   = stats.Int64("processor_resourcedetection_detector_duration", "How long detectors take to detect the resource", stats.UnitMilliseconds)
+	mDetectedAttributes = stats.Int64("processor_resourcedetection_detected_attributes", "Number of attributes detected by the last successful detector run", stats.UnitDimensionless)
+)
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+// MetricViews returns the views of the metrics recorded by the resource providers.
+func MetricViews() []*view.View {
+	detectorTags := []tag.Key{tagDetectorKey}
+	return []*view.View{
+		{
+			Name:        mDetectorRuns.Name(),
+			Measure:     mDetectorRuns,
+			Description: mDetectorRuns.Description(),
+			TagKeys:     []tag.Key{tagDetectorKey, tagResultKey},
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mDetectorDuration.Name(),
+			Measure:     mDetectorDuration,
+			Description: mDetectorDuration.Description(),
+			TagKeys:     detectorTags,
+			Aggregation: view.Distribution(0, 10, 50, 100, 250, 500, 1000, 2500, 5000, 10000),
+		},
+		{
+			Name:        mDetectedAttributes.Name(),
+			Measure:     mDetectedAttributes,
+			Description: mDetectedAttributes.Description(),
+			TagKeys:     detectorTags,
+			Aggregation: view.LastValue(),
+		},
+	}
+}
+
+// recordDetection records the outcome of a single detector run.
+func recordDetection(detectorType DetectorType, duration time.Duration, res pdata.Resource, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultFailure
+	}
+
+	ctx, _ := tag.New(context.Background(), tag.Upsert(tagDetectorKey, string(detectorType)))
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(tagResultKey, result)}, mDetectorRuns.M(1))
+	stats.Record(ctx, mDetectorDuration.M(duration.Milliseconds()))
+	if err == nil {
+		stats.Record(ctx, mDetectedAttributes.M(int64(res.Attributes().Len())))
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestDetectorMetrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	ok := &MockDetector{}
+	ok.On("Detect").Return(NewResource(map[string]interface{}{"a": "1", "b": "2"}), nil)
+	failing := &MockDetector{}
+	failing.On("Detect").Return(pdata.NewResource(), errors.New("err1"))
+
+	detectors := []ConfiguredDetector{
+		{Type: "metricsok", Detector: ok},
+		{Type: "metricsfailing", Detector: failing, Settings: DetectorSettings{Optional: true}},
+	}
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second}, detectors...)
+	_, err := p.Get(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	rows, err := view.RetrieveData(mDetectorRuns.Name())
+	require.NoError(t, err)
+	runs := map[string]float64{}
+	for _, row := range rows {
+		runs[tagValue(row.Tags, tagDetectorKey)+"/"+tagValue(row.Tags, tagResultKey)] = row.Data.(*view.SumData).Value
+	}
+	assert.Equal(t, float64(1), runs["metricsok/success"])
+	assert.Equal(t, float64(1), runs["metricsfailing/failure"])
+
+	rows, err = view.RetrieveData(mDetectedAttributes.Name())
+	require.NoError(t, err)
+	attributes := map[string]float64{}
+	for _, row := range rows {
+		attributes[tagValue(row.Tags, tagDetectorKey)] = row.Data.(*view.LastValueData).Value
+	}
+	assert.Equal(t, map[string]float64{"metricsok": 2}, attributes)
+
+	rows, err = view.RetrieveData(mDetectorDuration.Name())
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+}
+
+func tagValue(tags []tag.Tag, key tag.Key) string {
+	for _, t := range tags {
+		if t.Key == key {
+			return t.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const (
+	// OCI IMDS v2 instance endpoint, see https://docs.oracle.com/en-us/iaas/Content/Compute/Tasks/gettingmetadata.htm
+	metadataEndpoint = "http://169.254.169.254/opc/v2/instance/"
+)
+
+// Provider gets metadata from the OCI IMDS
+type Provider interface {
+	Metadata(context.Context) (*InstanceMetadata, error)
+}
+
+type ociProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider that uses the given HTTP client,
+// or a default client if it is nil
+func NewProvider(client *http.Client) Provider {
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &ociProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   client,
+	}
+}
+
+// InstanceMetadata is the OCI IMDS instance metadata response format
+type InstanceMetadata struct {
+	ID                  string `json:"id"`
+	DisplayName         string `json:"displayName"`
+	Hostname            string `json:"hostname"`
+	CompartmentID       string `json:"compartmentId"`
+	Shape               string `json:"shape"`
+	Image               string `json:"image"`
+	Region              string `json:"region"`
+	CanonicalRegionName string `json:"canonicalRegionName"`
+	AvailabilityDomain  string `json:"availabilityDomain"`
+	FaultDomain         string `json:"faultDomain"`
+}
+
+// Metadata queries the OCI IMDS v2 endpoint and parses the instance metadata
+func (p *ociProviderImpl) Metadata(ctx context.Context) (*InstanceMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	// IMDS v2 rejects requests without this header
+	req.Header.Add("Authorization", "Bearer Oracle")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OCI IMDS: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		//lint:ignore ST1005 OCI is an acronym here
+		return nil, fmt.Errorf("OCI IMDS replied with status code: %s", resp.Status)
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OCI IMDS reply: %v", err)
+	}
+
+	var metadata *InstanceMetadata
+	if err = json.Unmarshal(respBody, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode OCI IMDS reply: %v", err)
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider(nil)
+	assert.NotNil(t, provider)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	provider := &ociProviderImpl{endpoint: ts.URL, client: &http.Client{}}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "{")
+	}))
+	defer ts.Close()
+
+	provider := &ociProviderImpl{endpoint: ts.URL, client: &http.Client{}}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer Oracle" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{
+			"availabilityDomain": "EMIr:PHX-AD-1",
+			"faultDomain": "FAULT-DOMAIN-3",
+			"compartmentId": "ocid1.compartment.oc1..example",
+			"displayName": "my-instance",
+			"hostname": "my-instance",
+			"id": "ocid1.instance.oc1.phx.example",
+			"image": "ocid1.image.oc1.phx.example",
+			"region": "phx",
+			"canonicalRegionName": "us-phoenix-1",
+			"shape": "VM.Standard2.1"
+		}`)
+	}))
+	defer ts.Close()
+
+	provider := &ociProviderImpl{endpoint: ts.URL, client: &http.Client{}}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &InstanceMetadata{
+		ID:                  "ocid1.instance.oc1.phx.example",
+		DisplayName:         "my-instance",
+		Hostname:            "my-instance",
+		CompartmentID:       "ocid1.compartment.oc1..example",
+		Shape:               "VM.Standard2.1",
+		Image:               "ocid1.image.oc1.phx.example",
+		Region:              "phx",
+		CanonicalRegionName: "us-phoenix-1",
+		AvailabilityDomain:  "EMIr:PHX-AD-1",
+		FaultDomain:         "FAULT-DOMAIN-3",
+	}, metadata)
+}
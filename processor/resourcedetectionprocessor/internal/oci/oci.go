@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is the detector type string
+	TypeStr = "oci"
+
+	cloudProviderOCI       = "oracle_cloud"
+	cloudPlatformOCIVM     = "oracle_cloud_compute"
+	attributeCompartmentID = "oci.compartment.id"
+	attributeFaultDomain   = "oci.fault_domain"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is an OCI metadata detector
+type Detector struct {
+	provider Provider
+	logger   *zap.Logger
+}
+
+// NewDetector creates a new OCI metadata detector
+func NewDetector(p component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	return &Detector{provider: NewProvider(client), logger: p.Logger}, nil
+}
+
+// Detect detects OCI compute instance metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	attrs := res.Attributes()
+
+	instance, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("OCI detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return res, nil
+	}
+
+	region := instance.CanonicalRegionName
+	if region == "" {
+		region = instance.Region
+	}
+	hostname := instance.Hostname
+	if hostname == "" {
+		hostname = instance.DisplayName
+	}
+
+	attrs.InsertString(conventions.AttributeCloudProvider, cloudProviderOCI)
+	attrs.InsertString(conventions.AttributeCloudPlatform, cloudPlatformOCIVM)
+	attrs.InsertString(conventions.AttributeCloudRegion, region)
+	attrs.InsertString(conventions.AttributeCloudAvailabilityZone, instance.AvailabilityDomain)
+	attrs.InsertString(conventions.AttributeHostID, instance.ID)
+	attrs.InsertString(conventions.AttributeHostName, hostname)
+	attrs.InsertString(conventions.AttributeHostType, instance.Shape)
+	if instance.Image != "" {
+		attrs.InsertString(conventions.AttributeHostImageID, instance.Image)
+	}
+	attrs.InsertString(attributeCompartmentID, instance.CompartmentID)
+	if instance.FaultDomain != "" {
+		attrs.InsertString(attributeFaultDomain, instance.FaultDomain)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockProvider struct {
+	mock.Mock
+}
+
+func (m *mockProvider) Metadata(context.Context) (*InstanceMetadata, error) {
+	args := m.MethodCalled("Metadata")
+	arg := args.Get(0)
+	var im *InstanceMetadata
+	if arg != nil {
+		im = arg.(*InstanceMetadata)
+	}
+	return im, args.Error(1)
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetectOCI(t *testing.T) {
+	mp := &mockProvider{}
+	mp.On("Metadata").Return(&InstanceMetadata{
+		ID:                  "ocid1.instance.oc1.phx.example",
+		DisplayName:         "my-instance",
+		CompartmentID:       "ocid1.compartment.oc1..example",
+		Shape:               "VM.Standard2.1",
+		Image:               "ocid1.image.oc1.phx.example",
+		Region:              "phx",
+		CanonicalRegionName: "us-phoenix-1",
+		AvailabilityDomain:  "EMIr:PHX-AD-1",
+		FaultDomain:         "FAULT-DOMAIN-3",
+	}, nil)
+
+	detector := &Detector{provider: mp, logger: zap.NewNop()}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	mp.AssertExpectations(t)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":          "oracle_cloud",
+		"cloud.platform":          "oracle_cloud_compute",
+		"cloud.region":            "us-phoenix-1",
+		"cloud.availability_zone": "EMIr:PHX-AD-1",
+		"host.id":                 "ocid1.instance.oc1.phx.example",
+		"host.name":               "my-instance",
+		"host.type":               "VM.Standard2.1",
+		"host.image.id":           "ocid1.image.oc1.phx.example",
+		"oci.compartment.id":      "ocid1.compartment.oc1..example",
+		"oci.fault_domain":        "FAULT-DOMAIN-3",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectError(t *testing.T) {
+	mp := &mockProvider{}
+	mp.On("Metadata").Return(nil, errors.New("connection refused"))
+
+	detector := &Detector{provider: mp, logger: zap.NewNop()}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
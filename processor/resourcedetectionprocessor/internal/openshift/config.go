@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openshift
+
+import (
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Config defines user-specified configurations unique to the OpenShift detector
+type Config struct {
+	// Address is the address of the OpenShift API server.
+	// Defaults to the in-cluster address from KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT.
+	Address string `mapstructure:"address"`
+
+	// Token is the bearer token used to authenticate to the API server.
+	// Defaults to the token of the pod's service account, which is re-read on every detection.
+	Token string `mapstructure:"token"`
+
+	// TLSSettings configures the connection to the API server.
+	// If no CA file is set, the CA of the pod's service account is used.
+	TLSSettings configtls.TLSClientSetting `mapstructure:"tls"`
+}
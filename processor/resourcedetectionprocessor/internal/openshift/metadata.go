@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openshift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// infrastructurePath is the path of the cluster-scoped Infrastructure object,
+// see https://docs.openshift.com/container-platform/4.7/rest_api/config_apis/infrastructure-config-openshift-io-v1.html
+const infrastructurePath = "/apis/config.openshift.io/v1/infrastructures/cluster"
+
+// Provider gets cluster metadata from the OpenShift API
+type Provider interface {
+	Infrastructure(context.Context) (*InfrastructureAPIResponse, error)
+}
+
+type openshiftProviderImpl struct {
+	address   string
+	token     string
+	tokenPath string
+	client    *http.Client
+}
+
+// NewProvider creates a new metadata provider for the API server at address. Requests are
+// authenticated with token, or if it is empty with the token read from tokenPath.
+func NewProvider(address, token, tokenPath string, client *http.Client) Provider {
+	return &openshiftProviderImpl{
+		address:   address,
+		token:     token,
+		tokenPath: tokenPath,
+		client:    client,
+	}
+}
+
+// InfrastructureAPIResponse is the subset of the Infrastructure object used by the detector
+type InfrastructureAPIResponse struct {
+	Status InfrastructureStatus `json:"status"`
+}
+
+// InfrastructureStatus holds the cluster-wide infrastructure information
+type InfrastructureStatus struct {
+	InfrastructureName string                 `json:"infrastructureName"`
+	PlatformStatus     InfrastructurePlatform `json:"platformStatus"`
+}
+
+// InfrastructurePlatform holds the platform specific infrastructure information
+type InfrastructurePlatform struct {
+	Type  string            `json:"type"`
+	AWS   *RegionalPlatform `json:"aws,omitempty"`
+	GCP   *RegionalPlatform `json:"gcp,omitempty"`
+	IBM   *IBMCloudPlatform `json:"ibmcloud,omitempty"`
+	Azure *AzurePlatform    `json:"azure,omitempty"`
+}
+
+// RegionalPlatform holds the region of platforms that expose one
+type RegionalPlatform struct {
+	Region string `json:"region"`
+}
+
+// IBMCloudPlatform holds the IBM Cloud specific infrastructure information
+type IBMCloudPlatform struct {
+	Location string `json:"location"`
+}
+
+// AzurePlatform holds the Azure specific infrastructure information
+type AzurePlatform struct {
+	ResourceGroupName string `json:"resourceGroupName"`
+}
+
+// Infrastructure queries the OpenShift API for the cluster infrastructure object
+func (p *openshiftProviderImpl) Infrastructure(ctx context.Context) (*InfrastructureAPIResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.address+infrastructurePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	token, err := p.bearerToken()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OpenShift API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		//lint:ignore ST1005 OpenShift is a capitalized proper noun here
+		return nil, fmt.Errorf("OpenShift API replied with status code: %s", resp.Status)
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenShift API reply: %w", err)
+	}
+
+	var infra InfrastructureAPIResponse
+	if err = json.Unmarshal(respBody, &infra); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenShift API reply: %w", err)
+	}
+
+	return &infra, nil
+}
+
+// bearerToken returns the configured token, or reads the token file. The file is read on
+// every request since projected service account tokens are rotated.
+func (p *openshiftProviderImpl) bearerToken() (string, error) {
+	if p.token != "" || p.tokenPath == "" {
+		return p.token, nil
+	}
+
+	token, err := ioutil.ReadFile(p.tokenPath)
+	if err != nil {
+		return "", fmt.Errorf("failed reading service account token: %w", err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
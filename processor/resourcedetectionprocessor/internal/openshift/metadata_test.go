@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openshift
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfrastructure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, infrastructurePath, r.URL.Path)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		w.Write([]byte(`{
+			"apiVersion": "config.openshift.io/v1",
+			"kind": "Infrastructure",
+			"status": {
+				"infrastructureName": "ocp-cluster-x7k2p",
+				"platformStatus": {"type": "AWS", "aws": {"region": "us-east-1"}}
+			}
+		}`))
+	}))
+	defer ts.Close()
+
+	p := NewProvider(ts.URL, "test-token", "", ts.Client())
+	infra, err := p.Infrastructure(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &InfrastructureAPIResponse{
+		Status: InfrastructureStatus{
+			InfrastructureName: "ocp-cluster-x7k2p",
+			PlatformStatus: InfrastructurePlatform{
+				Type: "AWS",
+				AWS:  &RegionalPlatform{Region: "us-east-1"},
+			},
+		},
+	}, infra)
+}
+
+func TestInfrastructure_BadStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	p := NewProvider(ts.URL, "test-token", "", ts.Client())
+	_, err := p.Infrastructure(context.Background())
+	assert.EqualError(t, err, "OpenShift API replied with status code: 403 Forbidden")
+}
+
+func TestInfrastructure_BadResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not json"))
+	}))
+	defer ts.Close()
+
+	p := NewProvider(ts.URL, "test-token", "", ts.Client())
+	_, err := p.Infrastructure(context.Background())
+	assert.Error(t, err)
+}
+
+func TestInfrastructure_NullResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("null"))
+	}))
+	defer ts.Close()
+
+	p := NewProvider(ts.URL, "test-token", "", ts.Client())
+	infra, err := p.Infrastructure(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &InfrastructureAPIResponse{}, infra)
+}
+
+func TestInfrastructure_TokenFile(t *testing.T) {
+	var tokens []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "openshift")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	tokenPath := filepath.Join(dir, "token")
+
+	p := NewProvider(ts.URL, "", tokenPath, ts.Client())
+	_, err = p.Infrastructure(context.Background())
+	assert.EqualError(t, err, "failed reading service account token: open "+tokenPath+": no such file or directory")
+
+	require.NoError(t, ioutil.WriteFile(tokenPath, []byte("token-1\n"), 0600))
+	_, err = p.Infrastructure(context.Background())
+	require.NoError(t, err)
+
+	// the token is rotated
+	require.NoError(t, ioutil.WriteFile(tokenPath, []byte("token-2\n"), 0600))
+	_, err = p.Infrastructure(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, tokens)
+}
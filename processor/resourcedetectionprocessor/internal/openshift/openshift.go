@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openshift
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "openshift"
+
+	cloudPlatformOpenShift = "openshift"
+
+	serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceAccountCAPath    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is an OpenShift cluster metadata detector
+type Detector struct {
+	provider Provider
+}
+
+// NewDetector returns a resource detector that queries the OpenShift infrastructure API
+// for information about the cluster.
+func NewDetector(_ component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+
+	if cfg.Address == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return nil, fmt.Errorf("no address configured and KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are not defined")
+		}
+		cfg.Address = "https://" + net.JoinHostPort(host, port)
+	}
+
+	if cfg.TLSSettings.CAFile == "" && !cfg.TLSSettings.Insecure {
+		cfg.TLSSettings.CAFile = serviceAccountCAPath
+	}
+
+	tlsCfg, err := cfg.TLSSettings.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}}
+	return &Detector{provider: NewProvider(cfg.Address, cfg.Token, serviceAccountTokenPath, client)}, nil
+}
+
+// Detect returns a Resource describing the OpenShift cluster the collector is running in.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	infra, err := d.provider.Infrastructure(ctx)
+	if err != nil {
+		return res, fmt.Errorf("failed getting OpenShift infrastructure: %w", err)
+	}
+
+	attrs := res.Attributes()
+	attrs.InsertString(conventions.AttributeCloudPlatform, cloudPlatformOpenShift)
+	if infra.Status.InfrastructureName != "" {
+		attrs.InsertString(conventions.AttributeK8sCluster, infra.Status.InfrastructureName)
+	}
+
+	platform := infra.Status.PlatformStatus
+	if provider := cloudProvider(platform.Type); provider != "" {
+		attrs.InsertString(conventions.AttributeCloudProvider, provider)
+	}
+
+	var region string
+	switch {
+	case platform.AWS != nil:
+		region = platform.AWS.Region
+	case platform.GCP != nil:
+		region = platform.GCP.Region
+	case platform.IBM != nil:
+		region = platform.IBM.Location
+	}
+	if region != "" {
+		attrs.InsertString(conventions.AttributeCloudRegion, region)
+	}
+
+	return res, nil
+}
+
+// cloudProvider maps the OpenShift platform type to the cloud.provider convention.
+func cloudProvider(platformType string) string {
+	switch strings.ToLower(platformType) {
+	case "aws":
+		return conventions.AttributeCloudProviderAWS
+	case "azure":
+		return conventions.AttributeCloudProviderAzure
+	case "gcp":
+		return conventions.AttributeCloudProviderGCP
+	case "ibmcloud":
+		return "ibm_cloud"
+	case "", "none", "baremetal":
+		return ""
+	default:
+		return strings.ToLower(platformType)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openshift
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockProvider struct {
+	mock.Mock
+}
+
+func (m *mockProvider) Infrastructure(_ context.Context) (*InfrastructureAPIResponse, error) {
+	args := m.MethodCalled("Infrastructure")
+	arg := args.Get(0)
+	var infra *InfrastructureAPIResponse
+	if arg != nil {
+		infra = arg.(*InfrastructureAPIResponse)
+	}
+	return infra, args.Error(1)
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform InfrastructurePlatform
+		expected map[string]interface{}
+	}{
+		{
+			name:     "aws",
+			platform: InfrastructurePlatform{Type: "AWS", AWS: &RegionalPlatform{Region: "us-east-1"}},
+			expected: map[string]interface{}{
+				"cloud.provider":   "aws",
+				"cloud.platform":   "openshift",
+				"cloud.region":     "us-east-1",
+				"k8s.cluster.name": "ocp-cluster-x7k2p",
+			},
+		},
+		{
+			name:     "gcp",
+			platform: InfrastructurePlatform{Type: "GCP", GCP: &RegionalPlatform{Region: "europe-west1"}},
+			expected: map[string]interface{}{
+				"cloud.provider":   "gcp",
+				"cloud.platform":   "openshift",
+				"cloud.region":     "europe-west1",
+				"k8s.cluster.name": "ocp-cluster-x7k2p",
+			},
+		},
+		{
+			name:     "azure",
+			platform: InfrastructurePlatform{Type: "Azure", Azure: &AzurePlatform{ResourceGroupName: "rg"}},
+			expected: map[string]interface{}{
+				"cloud.provider":   "azure",
+				"cloud.platform":   "openshift",
+				"k8s.cluster.name": "ocp-cluster-x7k2p",
+			},
+		},
+		{
+			name:     "bare metal",
+			platform: InfrastructurePlatform{Type: "BareMetal"},
+			expected: map[string]interface{}{
+				"cloud.platform":   "openshift",
+				"k8s.cluster.name": "ocp-cluster-x7k2p",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp := &mockProvider{}
+			mp.On("Infrastructure").Return(&InfrastructureAPIResponse{
+				Status: InfrastructureStatus{InfrastructureName: "ocp-cluster-x7k2p", PlatformStatus: tt.platform},
+			}, nil)
+
+			d := &Detector{provider: mp}
+			res, err := d.Detect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, internal.AttributesToMap(res.Attributes()))
+		})
+	}
+}
+
+func TestDetect_Error(t *testing.T) {
+	mp := &mockProvider{}
+	mp.On("Infrastructure").Return(nil, errors.New("connection refused"))
+
+	d := &Detector{provider: mp}
+	res, err := d.Detect(context.Background())
+	assert.EqualError(t, err, "failed getting OpenShift infrastructure: connection refused")
+	assert.Equal(t, 0, res.Attributes().Len())
+}
@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podassociation
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+// Config configures resolving the pod that sent a batch of data from its client IP.
+type Config struct {
+	k8sconfig.APIConfig `mapstructure:",squash"`
+
+	// Enabled indicates whether the attributes of the pod that sent a batch of data
+	// should be added to its resource. Defaults to false.
+	Enabled bool `mapstructure:"enabled"`
+}
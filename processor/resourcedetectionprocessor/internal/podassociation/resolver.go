@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package podassociation resolves the Kubernetes pod that sent data to the collector,
+// so that gateway collectors can add pod attributes that the sender did not set itself.
+package podassociation
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+// ipIndex is the name of the informer index of pods by IP.
+const ipIndex = "ip"
+
+// Resolver resolves client IPs to the resource attributes of the pods they belong to,
+// from a cache of all pods that is kept up to date through the Kubernetes API.
+type Resolver struct {
+	informer cache.SharedIndexInformer
+
+	startOnce sync.Once
+	stopCh    chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewResolver creates a Resolver that connects to the Kubernetes API as configured in cfg.
+func NewResolver(cfg Config) (*Resolver, error) {
+	if cfg.AuthType == "" {
+		cfg.AuthType = k8sconfig.AuthTypeServiceAccount
+	}
+
+	client, err := k8sconfig.MakeClient(cfg.APIConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newResolver(client)
+}
+
+func newResolver(client kubernetes.Interface) (*Resolver, error) {
+	informer := informers.NewSharedInformerFactory(client, 0).Core().V1().Pods().Informer()
+	if err := informer.AddIndexers(cache.Indexers{ipIndex: podIPIndexFunc}); err != nil {
+		return nil, err
+	}
+	return &Resolver{informer: informer, stopCh: make(chan struct{})}, nil
+}
+
+// podIPIndexFunc indexes pods by their IP. Pods on the host network share the IP of
+// their node, so they cannot be told apart and are not indexed.
+func podIPIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || pod.Spec.HostNetwork || pod.Status.PodIP == "" {
+		return nil, nil
+	}
+	return []string{pod.Status.PodIP}, nil
+}
+
+// Start starts watching the pods. Only the first call has an effect.
+func (r *Resolver) Start() {
+	r.startOnce.Do(func() {
+		go r.informer.Run(r.stopCh)
+	})
+}
+
+// Shutdown stops watching the pods. Only the first call has an effect.
+func (r *Resolver) Shutdown() {
+	r.stopOnce.Do(func() {
+		close(r.stopCh)
+	})
+}
+
+// Resource returns the resource attributes of the running pod with the given IP, and
+// whether such a pod was found.
+func (r *Resolver) Resource(ip string) (pdata.Resource, bool) {
+	objs, err := r.informer.GetIndexer().ByIndex(ipIndex, ip)
+	if err != nil {
+		return pdata.Resource{}, false
+	}
+
+	for _, obj := range objs {
+		pod := obj.(*corev1.Pod)
+		// the IP of a completed pod may already have been reused by a new one
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		res := pdata.NewResource()
+		attr := res.Attributes()
+		attr.InsertString(conventions.AttributeK8sPod, pod.Name)
+		attr.InsertString(conventions.AttributeK8sPodUID, string(pod.UID))
+		attr.InsertString(conventions.AttributeK8sNamespace, pod.Namespace)
+		attr.InsertString(conventions.AttributeK8sNodeName, pod.Spec.NodeName)
+		return res, true
+	}
+	return pdata.Resource{}, false
+}
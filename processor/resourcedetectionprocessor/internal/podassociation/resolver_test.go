@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podassociation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+func newPod(name, ip string, hostNetwork bool, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: "uid-" + name},
+		Spec:       corev1.PodSpec{NodeName: "node-1", HostNetwork: hostNetwork},
+		Status:     corev1.PodStatus{PodIP: ip, Phase: phase},
+	}
+}
+
+func TestResolver(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newPod("completed", "10.0.0.1", false, corev1.PodSucceeded),
+		newPod("app", "10.0.0.1", false, corev1.PodRunning),
+		newPod("host", "192.168.0.1", true, corev1.PodRunning),
+	)
+	r, err := newResolver(client)
+	require.NoError(t, err)
+	r.Start()
+	defer r.Shutdown()
+
+	require.Eventually(t, r.informer.HasSynced, time.Second, 10*time.Millisecond)
+
+	res, ok := r.Resource("10.0.0.1")
+	require.True(t, ok)
+	assert.Equal(t, map[string]interface{}{
+		"k8s.pod.name":       "app",
+		"k8s.pod.uid":        "uid-app",
+		"k8s.namespace.name": "default",
+		"k8s.node.name":      "node-1",
+	}, internal.AttributesToMap(res.Attributes()))
+
+	_, ok = r.Resource("192.168.0.1")
+	assert.False(t, ok)
+
+	_, ok = r.Resource("10.0.0.2")
+	assert.False(t, ok)
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
 )
@@ -37,6 +38,49 @@ type DetectorConfig interface{}
 
 type ResourceDetectorConfig interface {
 	GetConfigFromType(DetectorType) DetectorConfig
+	GetSettingsFromType(DetectorType) DetectorSettings
+}
+
+// DetectorSettings contains settings that apply to any detector, regardless of its type.
+type DetectorSettings struct {
+	// Optional indicates that a failure of the detector should be logged and
+	// ignored instead of failing the processor start. Defaults to false.
+	Optional bool `mapstructure:"optional"`
+
+	// Attributes selects which of the attributes detected by the detector are kept.
+	Attributes AttributesFilter `mapstructure:"attributes"`
+}
+
+// AttributesFilter selects attributes by key.
+type AttributesFilter struct {
+	// Include is the list of attribute keys to keep. If empty, all attributes are kept.
+	Include []string `mapstructure:"include"`
+	// Exclude is the list of attribute keys to drop. It is applied after Include.
+	Exclude []string `mapstructure:"exclude"`
+}
+
+// Matches returns true if an attribute with the given key passes the filter.
+func (f AttributesFilter) Matches(key string) bool {
+	if len(f.Include) > 0 && !containsString(f.Include, key) {
+		return false
+	}
+	return !containsString(f.Exclude, key)
+}
+
+func containsString(list []string, s string) bool {
+	for _, elem := range list {
+		if elem == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfiguredDetector is a detector along with its type and settings.
+type ConfiguredDetector struct {
+	Type     DetectorType
+	Detector Detector
+	Settings DetectorSettings
 }
 
 type DetectorFactory func(component.ProcessorCreateParams, DetectorConfig) (Detector, error)
@@ -50,9 +94,28 @@ func NewProviderFactory(detectors map[DetectorType]DetectorFactory) *ResourcePro
 	return &ResourceProviderFactory{detectors: detectors}
 }
 
+// ProviderSettings configures how a ResourceProvider runs its detectors and post-processes
+// the detected resource.
+type ProviderSettings struct {
+	// Timeout is the maximum time a detection may take.
+	Timeout time.Duration
+	// DetectConcurrently runs the detectors in parallel instead of one after another.
+	DetectConcurrently bool
+	// RefreshInterval is how often the detection is re-run in the background, zero disables refreshing.
+	RefreshInterval time.Duration
+	// Cache persists the detected resource, nil disables caching.
+	Cache *ResourceCache
+	// AttributeRenames are applied to the detected attributes, see AttributeRenames.
+	AttributeRenames map[string]string
+	// AttributeTransforms are applied to the detected attributes after the renames.
+	AttributeTransforms []AttributeTransform
+	// AttributeConversions are applied to the detected attributes after the transforms.
+	AttributeConversions []AttributeConversion
+}
+
 func (f *ResourceProviderFactory) CreateResourceProvider(
 	params component.ProcessorCreateParams,
-	timeout time.Duration,
+	settings ProviderSettings,
 	detectorConfigs ResourceDetectorConfig,
 	detectorTypes ...DetectorType) (*ResourceProvider, error) {
 	detectors, err := f.getDetectors(params, detectorConfigs, detectorTypes)
@@ -60,12 +123,12 @@ func (f *ResourceProviderFactory) CreateResourceProvider(
 		return nil, err
 	}
 
-	provider := NewResourceProvider(params.Logger, timeout, detectors...)
+	provider := NewResourceProvider(params.Logger, settings, detectors...)
 	return provider, nil
 }
 
-func (f *ResourceProviderFactory) getDetectors(params component.ProcessorCreateParams, detectorConfigs ResourceDetectorConfig, detectorTypes []DetectorType) ([]Detector, error) {
-	detectors := make([]Detector, 0, len(detectorTypes))
+func (f *ResourceProviderFactory) getDetectors(params component.ProcessorCreateParams, detectorConfigs ResourceDetectorConfig, detectorTypes []DetectorType) ([]ConfiguredDetector, error) {
+	detectors := make([]ConfiguredDetector, 0, len(detectorTypes))
 	for _, detectorType := range detectorTypes {
 		detectorFactory, ok := f.detectors[detectorType]
 		if !ok {
@@ -77,64 +140,348 @@ func (f *ResourceProviderFactory) getDetectors(params component.ProcessorCreateP
 			return nil, fmt.Errorf("failed creating detector type %q: %w", detectorType, err)
 		}
 
-		detectors = append(detectors, detector)
+		detectors = append(detectors, ConfiguredDetector{
+			Type:     detectorType,
+			Detector: detector,
+			Settings: detectorConfigs.GetSettingsFromType(detectorType),
+		})
 	}
 
 	return detectors, nil
 }
 
 type ResourceProvider struct {
-	logger           *zap.Logger
-	timeout          time.Duration
-	detectors        []Detector
-	detectedResource *resourceResult
-	once             sync.Once
+	logger               *zap.Logger
+	timeout              time.Duration
+	detectConcurrently   bool
+	refreshInterval      time.Duration
+	cache                *ResourceCache
+	attributeRenames     map[string]string
+	attributeTransforms  []AttributeTransform
+	attributeConversions []AttributeConversion
+	detectors            []ConfiguredDetector
+	detectedResource     *resourceResult
+	mu                   sync.RWMutex
+	once                 sync.Once
+	stopCh               chan struct{}
+	stopOnce             sync.Once
+	retryOnce            sync.Once
+
+	// retryInitialInterval and retryMaxInterval bound the backoff between detection retries.
+	retryInitialInterval time.Duration
+	retryMaxInterval     time.Duration
 }
 
 type resourceResult struct {
 	resource pdata.Resource
-	err      error
+	// sources maps each attribute of the resource to the detector that supplied it
+	sources map[string]string
+	err     error
 }
 
-func NewResourceProvider(logger *zap.Logger, timeout time.Duration, detectors ...Detector) *ResourceProvider {
+// sourceCache is the source of the attributes of a resource loaded from the cache.
+const sourceCache = "cache"
+
+func NewResourceProvider(logger *zap.Logger, settings ProviderSettings, detectors ...ConfiguredDetector) *ResourceProvider {
 	return &ResourceProvider{
-		logger:    logger,
-		timeout:   timeout,
-		detectors: detectors,
+		logger:               logger,
+		timeout:              settings.Timeout,
+		detectConcurrently:   settings.DetectConcurrently,
+		refreshInterval:      settings.RefreshInterval,
+		cache:                settings.Cache,
+		attributeRenames:     settings.AttributeRenames,
+		attributeTransforms:  settings.AttributeTransforms,
+		attributeConversions: settings.AttributeConversions,
+		detectors:            detectors,
+		stopCh:               make(chan struct{}),
+
+		retryInitialInterval: time.Second,
+		retryMaxInterval:     5 * time.Minute,
 	}
 }
 
-func (p *ResourceProvider) Get(ctx context.Context) (pdata.Resource, error) {
+// Get returns the detected resource. Detection is run on the first call only; if a
+// refresh interval is configured, later calls return the most recently detected resource.
+// If a cache is configured, the storage extension it uses is looked up in host.
+func (p *ResourceProvider) Get(ctx context.Context, host component.Host) (pdata.Resource, error) {
 	p.once.Do(func() {
+		if p.cache != nil {
+			if err := p.cache.start(ctx, host); err != nil {
+				p.setDetectedResource(&resourceResult{resource: pdata.NewResource(), err: err})
+				return
+			}
+		}
+
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, p.timeout)
 		defer cancel()
-		p.detectResource(ctx)
+
+		res, sources, err := p.detectResource(ctx)
+		if err != nil {
+			res, err = p.getCachedResource(ctx, err)
+			sources = attributeSources(res, sourceCache)
+		}
+		p.setDetectedResource(&resourceResult{resource: res, sources: sources, err: err})
+
+		if err == nil && p.refreshInterval > 0 {
+			go p.refreshLoop()
+		}
 	})
 
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	return p.detectedResource.resource, p.detectedResource.err
 }
 
-func (p *ResourceProvider) detectResource(ctx context.Context) {
-	p.detectedResource = &resourceResult{}
+// Resource returns the most recently detected resource, or an empty resource if
+// detection has not been run yet.
+func (p *ResourceProvider) Resource() pdata.Resource {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.detectedResource == nil {
+		return pdata.NewResource()
+	}
+	return p.detectedResource.resource
+}
+
+// AttributeSources returns, for each attribute of the most recently detected resource,
+// the type of the detector that supplied it.
+func (p *ResourceProvider) AttributeSources() map[string]string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.detectedResource == nil {
+		return map[string]string{}
+	}
+	return p.detectedResource.sources
+}
+
+// RetryInBackground re-runs the detection with exponential backoff until it succeeds or the
+// provider is shut down, for use after the first detection failed. The detected resource is
+// then swapped in, and the periodic refresh is started if a refresh interval is configured.
+// Calls after the first one have no effect.
+func (p *ResourceProvider) RetryInBackground() {
+	p.retryOnce.Do(func() {
+		go p.retryLoop()
+	})
+}
+
+func (p *ResourceProvider) retryLoop() {
+	interval := p.retryInitialInterval
+	for {
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-p.stopCh:
+			timer.Stop()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		res, sources, err := p.detectResource(ctx)
+		cancel()
+		if err == nil {
+			p.setDetectedResource(&resourceResult{resource: res, sources: sources})
+			if p.refreshInterval > 0 {
+				p.refreshLoop()
+			}
+			return
+		}
+
+		interval *= 2
+		if interval > p.retryMaxInterval {
+			interval = p.retryMaxInterval
+		}
+		p.logger.Warn("failed detecting resource information, retrying", zap.Duration("retry_in", interval), zap.Error(err))
+	}
+}
+
+// Shutdown stops the periodic refresh of the detected resource and the detection retries, if any.
+func (p *ResourceProvider) Shutdown() {
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+	})
+}
+
+func (p *ResourceProvider) setDetectedResource(result *resourceResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.detectedResource = result
+}
+
+// getCachedResource returns the cached resource in place of a failed detection if one is
+// available and not too old; otherwise it returns the detection error.
+func (p *ResourceProvider) getCachedResource(ctx context.Context, detectErr error) (pdata.Resource, error) {
+	if p.cache == nil {
+		return pdata.NewResource(), detectErr
+	}
 
+	res, ok, err := p.cache.load(ctx)
+	if err != nil {
+		p.logger.Warn("failed loading cached resource information", zap.Error(err))
+	}
+	if !ok {
+		return pdata.NewResource(), detectErr
+	}
+
+	p.logger.Warn("failed detecting resource information, using cached resource", zap.Error(detectErr))
+	return res, nil
+}
+
+// refreshLoop re-runs the detectors every refresh interval until the provider is shut down.
+// A failed refresh keeps the previously detected resource.
+func (p *ResourceProvider) refreshLoop() {
+	ticker := time.NewTicker(p.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.refresh()
+		case <-p.stopCh:
+			return
+		}
+	}
+}
+
+func (p *ResourceProvider) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	res, sources, err := p.detectResource(ctx)
+	if err != nil {
+		p.logger.Warn("failed refreshing resource information, keeping previously detected resource", zap.Error(err))
+		return
+	}
+
+	p.setDetectedResource(&resourceResult{resource: res, sources: sources})
+}
+
+// detectResource runs the detectors and merges their results. Along with the resource, it returns
+// the type of the detector that supplied each attribute.
+func (p *ResourceProvider) detectResource(ctx context.Context) (pdata.Resource, map[string]string, error) {
 	res := pdata.NewResource()
+	sources := map[string]string{}
 
 	p.logger.Info("began detecting resource information")
 
-	for _, detector := range p.detectors {
-		r, err := detector.Detect(ctx)
-		if err != nil {
-			p.detectedResource.err = err
-			return
+	var results []detectorResult
+	if p.detectConcurrently {
+		results = p.detectAllConcurrently(ctx)
+	} else {
+		results = p.detectAllSequentially(ctx)
+	}
+
+	var errs []error
+	for i, r := range results {
+		detector := p.detectors[i]
+		if r.err != nil {
+			if detector.Settings.Optional {
+				p.logger.Warn("optional detector failed, ignoring its result", zap.String("detector", string(detector.Type)), zap.Error(r.err))
+				continue
+			}
+			errs = append(errs, fmt.Errorf("failed detecting resource with detector type %q: %w", detector.Type, r.err))
+			continue
 		}
 
-		MergeResource(res, r, false)
+		mergeFilteredResource(res, r.resource, false, detector.Settings.Attributes)
+		res.Attributes().Range(func(k string, _ pdata.AttributeValue) bool {
+			if _, ok := sources[k]; !ok {
+				sources[k] = string(detector.Type)
+			}
+			return true
+		})
+	}
+
+	if len(errs) > 0 {
+		return pdata.NewResource(), nil, consumererror.Combine(errs)
+	}
+
+	renameAttributes(res.Attributes(), p.attributeRenames)
+	renameSources(sources, p.attributeRenames)
+	applyTransforms(res.Attributes(), p.attributeTransforms)
+	if err := applyConversions(res.Attributes(), p.attributeConversions); err != nil {
+		p.logger.Warn("failed converting detected attributes, keeping their values unchanged", zap.Error(err))
 	}
 
 	p.logger.Info("detected resource information", zap.Any("resource", AttributesToMap(res.Attributes())))
 
-	p.detectedResource.resource = res
+	if p.cache != nil {
+		if err := p.cache.store(ctx, res); err != nil {
+			p.logger.Warn("failed caching resource information", zap.Error(err))
+		}
+	}
+
+	return res, sources, nil
+}
+
+// attributeSources returns sources that attribute every attribute of res to source.
+func attributeSources(res pdata.Resource, source string) map[string]string {
+	sources := make(map[string]string, res.Attributes().Len())
+	res.Attributes().Range(func(k string, _ pdata.AttributeValue) bool {
+		sources[k] = source
+		return true
+	})
+	return sources
+}
+
+// detectorResult holds the outcome of a single detector run.
+type detectorResult struct {
+	resource pdata.Resource
+	err      error
+}
+
+// detectAllSequentially runs the detectors one after another and returns their results in detector order.
+func (p *ResourceProvider) detectAllSequentially(ctx context.Context) []detectorResult {
+	results := make([]detectorResult, 0, len(p.detectors))
+	for _, detector := range p.detectors {
+		results = append(results, runDetector(ctx, detector))
+	}
+	return results
+}
+
+// runDetector runs a single detector and records its duration and outcome.
+func runDetector(ctx context.Context, detector ConfiguredDetector) detectorResult {
+	start := time.Now()
+	r, err := detector.Detector.Detect(ctx)
+	recordDetection(detector.Type, time.Since(start), r, err)
+	return detectorResult{resource: r, err: err}
+}
+
+// detectAllConcurrently runs all detectors in parallel and returns their results in
+// detector order once every detector has completed or the context is done. Detectors
+// that have not completed by then are reported with the context error.
+func (p *ResourceProvider) detectAllConcurrently(ctx context.Context) []detectorResult {
+	type indexedResult struct {
+		index int
+		detectorResult
+	}
+
+	// buffered so that detectors finishing after ctx is done never block
+	resultsCh := make(chan indexedResult, len(p.detectors))
+	for i, detector := range p.detectors {
+		go func(i int, detector ConfiguredDetector) {
+			resultsCh <- indexedResult{index: i, detectorResult: runDetector(ctx, detector)}
+		}(i, detector)
+	}
+
+	results := make([]detectorResult, len(p.detectors))
+	completed := make([]bool, len(p.detectors))
+	for remaining := len(p.detectors); remaining > 0; remaining-- {
+		select {
+		case r := <-resultsCh:
+			results[r.index] = r.detectorResult
+			completed[r.index] = true
+		case <-ctx.Done():
+			for i := range results {
+				if !completed[i] {
+					results[i] = detectorResult{resource: pdata.NewResource(), err: ctx.Err()}
+				}
+			}
+			return results
+		}
+	}
+
+	return results
 }
 
 func AttributesToMap(am pdata.AttributeMap) map[string]interface{} {
@@ -175,12 +522,39 @@ func getSerializableArray(inArr pdata.AnyValueArray) []interface{} {
 }
 
 func MergeResource(to, from pdata.Resource, overrideTo bool) {
+	mergeFilteredResource(to, from, overrideTo, AttributesFilter{})
+}
+
+// MergeResourceWithOverrides merges from into to like MergeResource, except that the
+// attributes in overrideKeys always replace existing values in to, regardless of overrideTo.
+func MergeResourceWithOverrides(to, from pdata.Resource, overrideTo bool, overrideKeys map[string]struct{}) {
+	if len(overrideKeys) == 0 || overrideTo {
+		MergeResource(to, from, overrideTo)
+		return
+	}
+
+	toAttr := to.Attributes()
+	from.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+		if _, ok := overrideKeys[k]; ok {
+			toAttr.Upsert(k, v)
+		} else {
+			toAttr.Insert(k, v)
+		}
+		return true
+	})
+}
+
+// mergeFilteredResource merges the attributes of from that match the filter into to.
+func mergeFilteredResource(to, from pdata.Resource, overrideTo bool, filter AttributesFilter) {
 	if IsEmptyResource(from) {
 		return
 	}
 
 	toAttr := to.Attributes()
 	from.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+		if !filter.Matches(k) {
+			return true
+		}
 		if overrideTo {
 			toAttr.Upsert(k, v)
 		} else {
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
 )
@@ -45,6 +46,22 @@ func (d *mockDetectorConfig) GetConfigFromType(detectorType DetectorType) Detect
 	return nil
 }
 
+func (d *mockDetectorConfig) GetSettingsFromType(detectorType DetectorType) DetectorSettings {
+	return DetectorSettings{}
+}
+
+// requiredDetectors configures the given detectors as required detectors named after their position.
+func requiredDetectors(detectors ...Detector) []ConfiguredDetector {
+	configured := make([]ConfiguredDetector, 0, len(detectors))
+	for i, detector := range detectors {
+		configured = append(configured, ConfiguredDetector{
+			Type:     DetectorType(fmt.Sprintf("mockdetector%v", i)),
+			Detector: detector,
+		})
+	}
+	return configured
+}
+
 func TestDetect(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -95,10 +112,10 @@ func TestDetect(t *testing.T) {
 			}
 
 			f := NewProviderFactory(mockDetectors)
-			p, err := f.CreateResourceProvider(component.ProcessorCreateParams{Logger: zap.NewNop()}, time.Second, &mockDetectorConfig{}, mockDetectorTypes...)
+			p, err := f.CreateResourceProvider(component.ProcessorCreateParams{Logger: zap.NewNop()}, ProviderSettings{Timeout: time.Second}, &mockDetectorConfig{}, mockDetectorTypes...)
 			require.NoError(t, err)
 
-			got, err := p.Get(context.Background())
+			got, err := p.Get(context.Background(), componenttest.NewNopHost())
 			require.NoError(t, err)
 
 			tt.expectedResource.Attributes().Sort()
@@ -111,7 +128,7 @@ func TestDetect(t *testing.T) {
 func TestDetectResource_InvalidDetectorType(t *testing.T) {
 	mockDetectorKey := DetectorType("mock")
 	p := NewProviderFactory(map[DetectorType]DetectorFactory{})
-	_, err := p.CreateResourceProvider(component.ProcessorCreateParams{Logger: zap.NewNop()}, time.Second, &mockDetectorConfig{}, mockDetectorKey)
+	_, err := p.CreateResourceProvider(component.ProcessorCreateParams{Logger: zap.NewNop()}, ProviderSettings{Timeout: time.Second}, &mockDetectorConfig{}, mockDetectorKey)
 	require.EqualError(t, err, fmt.Sprintf("invalid detector key: %v", mockDetectorKey))
 }
 
@@ -122,7 +139,7 @@ func TestDetectResource_DetectoryFactoryError(t *testing.T) {
 			return nil, errors.New("creation failed")
 		},
 	})
-	_, err := p.CreateResourceProvider(component.ProcessorCreateParams{Logger: zap.NewNop()}, time.Second, &mockDetectorConfig{}, mockDetectorKey)
+	_, err := p.CreateResourceProvider(component.ProcessorCreateParams{Logger: zap.NewNop()}, ProviderSettings{Timeout: time.Second}, &mockDetectorConfig{}, mockDetectorKey)
 	require.EqualError(t, err, fmt.Sprintf("failed creating detector type %q: %v", mockDetectorKey, "creation failed"))
 }
 
@@ -133,9 +150,41 @@ func TestDetectResource_Error(t *testing.T) {
 	md2 := &MockDetector{}
 	md2.On("Detect").Return(pdata.NewResource(), errors.New("err1"))
 
-	p := NewResourceProvider(zap.NewNop(), time.Second, md1, md2)
-	_, err := p.Get(context.Background())
-	require.EqualError(t, err, "err1")
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second}, requiredDetectors(md1, md2)...)
+	_, err := p.Get(context.Background(), componenttest.NewNopHost())
+	require.EqualError(t, err, `failed detecting resource with detector type "mockdetector1": err1`)
+}
+
+func TestDetectResource_AggregateErrors(t *testing.T) {
+	md1 := &MockDetector{}
+	md1.On("Detect").Return(pdata.NewResource(), errors.New("err1"))
+
+	md2 := &MockDetector{}
+	md2.On("Detect").Return(NewResource(map[string]interface{}{"a": "1"}), nil)
+
+	md3 := &MockDetector{}
+	md3.On("Detect").Return(pdata.NewResource(), errors.New("err3"))
+
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second}, requiredDetectors(md1, md2, md3)...)
+	_, err := p.Get(context.Background(), componenttest.NewNopHost())
+	require.EqualError(t, err, `[failed detecting resource with detector type "mockdetector0": err1; failed detecting resource with detector type "mockdetector2": err3]`)
+	md3.AssertNumberOfCalls(t, "Detect", 1)
+}
+
+func TestDetectResource_OptionalDetector(t *testing.T) {
+	md1 := &MockDetector{}
+	md1.On("Detect").Return(pdata.NewResource(), errors.New("err1"))
+
+	md2 := &MockDetector{}
+	md2.On("Detect").Return(NewResource(map[string]interface{}{"a": "1"}), nil)
+
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second},
+		ConfiguredDetector{Type: "optional", Detector: md1, Settings: DetectorSettings{Optional: true}},
+		ConfiguredDetector{Type: "required", Detector: md2},
+	)
+	got, err := p.Get(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "1"}, AttributesToMap(got.Attributes()))
 }
 
 func TestMergeResource(t *testing.T) {
@@ -171,6 +220,66 @@ func TestMergeResource(t *testing.T) {
 	}
 }
 
+func TestMergeResourceWithOverrides(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		overrideTo   bool
+		overrideKeys map[string]struct{}
+		expected     map[string]interface{}
+	}{
+		{
+			name:     "no override keys",
+			expected: map[string]interface{}{"host.name": "incoming", "cloud.region": "incoming", "os.type": "LINUX"},
+		},
+		{
+			name:         "override selected keys",
+			overrideKeys: map[string]struct{}{"host.name": {}},
+			expected:     map[string]interface{}{"host.name": "detected", "cloud.region": "incoming", "os.type": "LINUX"},
+		},
+		{
+			name:         "override all keys",
+			overrideTo:   true,
+			overrideKeys: map[string]struct{}{"host.name": {}},
+			expected:     map[string]interface{}{"host.name": "detected", "cloud.region": "detected", "os.type": "LINUX"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			to := NewResource(map[string]interface{}{"host.name": "incoming", "cloud.region": "incoming"})
+			from := NewResource(map[string]interface{}{"host.name": "detected", "cloud.region": "detected", "os.type": "LINUX"})
+			MergeResourceWithOverrides(to, from, tt.overrideTo, tt.overrideKeys)
+			assert.Equal(t, tt.expected, AttributesToMap(to.Attributes()))
+		})
+	}
+}
+
+func TestDetectResource_AttributesFilter(t *testing.T) {
+	md1 := &MockDetector{}
+	md1.On("Detect").Return(NewResource(map[string]interface{}{"cloud.region": "us-west-2", "cloud.account.id": "1234", "host.id": "i-1234", "host.name": "ec2"}), nil)
+
+	md2 := &MockDetector{}
+	md2.On("Detect").Return(NewResource(map[string]interface{}{"host.name": "fqdn", "os.type": "LINUX"}), nil)
+
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second},
+		ConfiguredDetector{Type: "ec2", Detector: md1, Settings: DetectorSettings{
+			Attributes: AttributesFilter{Include: []string{"cloud.region", "cloud.account.id", "host.name"}, Exclude: []string{"host.name"}},
+		}},
+		ConfiguredDetector{Type: "system", Detector: md2, Settings: DetectorSettings{
+			Attributes: AttributesFilter{Exclude: []string{"os.type"}},
+		}},
+	)
+	got, err := p.Get(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"cloud.region": "us-west-2", "cloud.account.id": "1234", "host.name": "fqdn"}, AttributesToMap(got.Attributes()))
+}
+
+func TestAttributesFilter(t *testing.T) {
+	assert.True(t, AttributesFilter{}.Matches("a"))
+	assert.True(t, AttributesFilter{Include: []string{"a"}}.Matches("a"))
+	assert.False(t, AttributesFilter{Include: []string{"a"}}.Matches("b"))
+	assert.False(t, AttributesFilter{Exclude: []string{"a"}}.Matches("a"))
+	assert.False(t, AttributesFilter{Include: []string{"a"}, Exclude: []string{"a"}}.Matches("a"))
+}
+
 type MockParallelDetector struct {
 	mock.Mock
 	ch chan struct{}
@@ -200,7 +309,7 @@ func TestDetectResource_Parallel(t *testing.T) {
 	expectedResource := NewResource(map[string]interface{}{"a": "1", "b": "2", "c": "3"})
 	expectedResource.Attributes().Sort()
 
-	p := NewResourceProvider(zap.NewNop(), time.Second, md1, md2)
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second}, requiredDetectors(md1, md2)...)
 
 	// call p.Get multiple times
 	wg := &sync.WaitGroup{}
@@ -208,7 +317,7 @@ func TestDetectResource_Parallel(t *testing.T) {
 	for i := 0; i < iterations; i++ {
 		go func() {
 			defer wg.Done()
-			_, err := p.Get(context.Background())
+			_, err := p.Get(context.Background(), componenttest.NewNopHost())
 			require.NoError(t, err)
 		}()
 	}
@@ -226,6 +335,109 @@ func TestDetectResource_Parallel(t *testing.T) {
 	md2.AssertNumberOfCalls(t, "Detect", 1)
 }
 
+func TestDetectResource_DetectConcurrently(t *testing.T) {
+	md1 := NewMockParallelDetector()
+	md1.On("Detect").Return(NewResource(map[string]interface{}{"a": "1", "b": "2"}), nil)
+
+	md2 := NewMockParallelDetector()
+	md2.On("Detect").Return(NewResource(map[string]interface{}{"a": "11", "c": "3"}), nil)
+
+	expectedResource := NewResource(map[string]interface{}{"a": "1", "b": "2", "c": "3"})
+	expectedResource.Attributes().Sort()
+
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second, DetectConcurrently: true}, requiredDetectors(md1, md2)...)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		got, err := p.Get(context.Background(), componenttest.NewNopHost())
+		require.NoError(t, err)
+		got.Attributes().Sort()
+		assert.Equal(t, expectedResource, got)
+	}()
+
+	// release the detectors in reverse order, which would deadlock if they were run sequentially
+	md2.ch <- struct{}{}
+	md1.ch <- struct{}{}
+
+	<-done
+	md1.AssertNumberOfCalls(t, "Detect", 1)
+	md2.AssertNumberOfCalls(t, "Detect", 1)
+}
+
+func TestDetectResource_DetectConcurrentlyTimeout(t *testing.T) {
+	md1 := &MockDetector{}
+	md1.On("Detect").Return(NewResource(map[string]interface{}{"a": "1"}), nil)
+
+	// only released once the provider has given up, so it will not complete before the timeout
+	md2 := NewMockParallelDetector()
+	md2.On("Detect").Return(NewResource(map[string]interface{}{"b": "2"}), nil)
+	defer close(md2.ch)
+
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: 10 * time.Millisecond, DetectConcurrently: true}, requiredDetectors(md1, md2)...)
+	_, err := p.Get(context.Background(), componenttest.NewNopHost())
+	require.EqualError(t, err, `failed detecting resource with detector type "mockdetector1": context deadline exceeded`)
+}
+
+func TestDetectResource_Refresh(t *testing.T) {
+	md := &MockDetector{}
+	md.On("Detect").Return(NewResource(map[string]interface{}{"a": "1"}), nil).Once()
+	md.On("Detect").Return(pdata.NewResource(), errors.New("refresh failed")).Once()
+	md.On("Detect").Return(NewResource(map[string]interface{}{"a": "2"}), nil)
+
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second, RefreshInterval: 10 * time.Millisecond}, requiredDetectors(md)...)
+	defer p.Shutdown()
+
+	got, err := p.Get(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "1"}, AttributesToMap(got.Attributes()))
+
+	// the failed refresh keeps the first resource, the next one swaps it
+	assert.Eventually(t, func() bool {
+		got, err = p.Get(context.Background(), componenttest.NewNopHost())
+		return err == nil && assert.ObjectsAreEqual(map[string]interface{}{"a": "2"}, AttributesToMap(got.Attributes()))
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestDetectResource_RetryInBackground(t *testing.T) {
+	md := &MockDetector{}
+	md.On("Detect").Return(pdata.NewResource(), errors.New("err1")).Twice()
+	md.On("Detect").Return(NewResource(map[string]interface{}{"a": "1"}), nil)
+
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second}, requiredDetectors(md)...)
+	p.retryInitialInterval = time.Millisecond
+	p.retryMaxInterval = 5 * time.Millisecond
+	defer p.Shutdown()
+
+	_, err := p.Get(context.Background(), componenttest.NewNopHost())
+	require.Error(t, err)
+	assert.True(t, IsEmptyResource(p.Resource()))
+
+	p.RetryInBackground()
+	p.RetryInBackground()
+
+	assert.Eventually(t, func() bool {
+		got, err := p.Get(context.Background(), componenttest.NewNopHost())
+		return err == nil && assert.ObjectsAreEqual(map[string]interface{}{"a": "1"}, AttributesToMap(got.Attributes()))
+	}, time.Second, 5*time.Millisecond)
+	md.AssertNumberOfCalls(t, "Detect", 3)
+}
+
+func TestDetectResource_RetryInBackgroundShutdown(t *testing.T) {
+	md := &MockDetector{}
+	md.On("Detect").Return(pdata.NewResource(), errors.New("err1"))
+
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second}, requiredDetectors(md)...)
+	p.retryInitialInterval = time.Hour
+
+	_, err := p.Get(context.Background(), componenttest.NewNopHost())
+	require.Error(t, err)
+
+	p.RetryInBackground()
+	p.Shutdown()
+	md.AssertNumberOfCalls(t, "Detect", 1)
+}
+
 func TestAttributesToMap(t *testing.T) {
 	m := map[string]interface{}{
 		"str":    "a",
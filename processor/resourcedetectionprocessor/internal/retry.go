@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+const defaultInitialInterval = time.Second
+
+// RetrySettings configures retrying failed requests to a metadata endpoint with exponential backoff.
+type RetrySettings struct {
+	// MaxAttempts is the maximum number of attempts of a request, including the first one.
+	// Requests are not retried if it is 0 or 1. Defaults to 0.
+	MaxAttempts int `mapstructure:"max_attempts"`
+
+	// InitialInterval is the time to wait before the first retry, doubled for every further retry.
+	// Defaults to 1s.
+	InitialInterval time.Duration `mapstructure:"initial_interval"`
+
+	// Jitter is the fraction, between 0 and 1, by which each interval is randomly increased or decreased.
+	// Defaults to 0.
+	Jitter float64 `mapstructure:"jitter"`
+}
+
+// interval returns the time to wait after the given failed attempt, starting at 1.
+func (s RetrySettings) interval(attempt int) time.Duration {
+	interval := s.InitialInterval
+	if interval <= 0 {
+		interval = defaultInitialInterval
+	}
+	interval <<= attempt - 1
+	if s.Jitter > 0 {
+		interval = time.Duration(float64(interval) * (1 + s.Jitter*(2*rand.Float64()-1)))
+	}
+	return interval
+}
+
+// retryTransport retries requests that fail with a network error or a server error response.
+type retryTransport struct {
+	next     http.RoundTripper
+	settings RetrySettings
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// requests with a body can only be retried if the body can be read again
+	rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.settings.MaxAttempts || !rewindable || !shouldRetry(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(t.settings.interval(attempt))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// shouldRetry reports whether a request with the given outcome is worth retrying. Client errors
+// such as 404 are not retried, since they usually mean that the endpoint is not the expected one.
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
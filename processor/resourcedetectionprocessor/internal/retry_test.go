@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrySettings_Interval(t *testing.T) {
+	s := RetrySettings{InitialInterval: 100 * time.Millisecond}
+	assert.Equal(t, 100*time.Millisecond, s.interval(1))
+	assert.Equal(t, 200*time.Millisecond, s.interval(2))
+	assert.Equal(t, 400*time.Millisecond, s.interval(3))
+
+	assert.Equal(t, time.Second, RetrySettings{}.interval(1))
+
+	s.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		interval := s.interval(2)
+		assert.GreaterOrEqual(t, int64(interval), int64(100*time.Millisecond))
+		assert.LessOrEqual(t, int64(interval), int64(300*time.Millisecond))
+	}
+}
+
+func newRetryClient(t *testing.T, maxAttempts int) *http.Client {
+	client, err := HTTPClientSettings{Retry: RetrySettings{MaxAttempts: maxAttempts, InitialInterval: time.Millisecond}}.ToClient()
+	require.NoError(t, err)
+	return client
+}
+
+func TestRetryTransport_RetriesServerErrors(t *testing.T) {
+	var attempts int
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	resp, err := newRetryClient(t, 5).Post(ts.URL, "text/plain", strings.NewReader("payload"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []string{"payload", "payload", "payload"}, bodies)
+}
+
+func TestRetryTransport_MaxAttempts(t *testing.T) {
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	resp, err := newRetryClient(t, 3).Get(ts.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetryTransport_ClientErrorNotRetried(t *testing.T) {
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	resp, err := newRetryClient(t, 3).Get(ts.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryTransport_ContextCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	client, err := HTTPClientSettings{Retry: RetrySettings{MaxAttempts: 5, InitialInterval: time.Hour}}.ToClient()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	assert.Error(t, err)
+}
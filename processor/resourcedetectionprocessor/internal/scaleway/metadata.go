@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaleway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const (
+	// Scaleway instance metadata endpoint, see https://www.scaleway.com/en/docs/compute/instances/how-to/use-instance-metadata/
+	metadataEndpoint = "http://169.254.42.42/conf?format=json"
+)
+
+// Provider gets metadata from the Scaleway instance metadata API
+type Provider interface {
+	Metadata(context.Context) (*InstanceMetadata, error)
+}
+
+type scalewayProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider that uses the given HTTP client,
+// or a default client if it is nil
+func NewProvider(client *http.Client) Provider {
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &scalewayProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   client,
+	}
+}
+
+// InstanceMetadata is the Scaleway instance metadata response format
+type InstanceMetadata struct {
+	ID             string   `json:"id"`
+	Hostname       string   `json:"hostname"`
+	CommercialType string   `json:"commercial_type"`
+	Tags           []string `json:"tags"`
+	Location       Location `json:"location"`
+}
+
+// Location is the location of the instance within the Scaleway infrastructure
+type Location struct {
+	ZoneID string `json:"zone_id"`
+}
+
+// Metadata queries the instance metadata endpoint and parses the output
+func (p *scalewayProviderImpl) Metadata(ctx context.Context) (*InstanceMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Scaleway metadata API: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		//lint:ignore ST1005 Scaleway is a capitalized proper noun here
+		return nil, fmt.Errorf("Scaleway metadata API replied with status code: %s", resp.Status)
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Scaleway metadata API reply: %v", err)
+	}
+
+	var metadata *InstanceMetadata
+	if err = json.Unmarshal(respBody, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode Scaleway metadata API reply: %v", err)
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider(nil)
+	assert.NotNil(t, provider)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	provider := &scalewayProviderImpl{endpoint: ts.URL, client: &http.Client{}}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "{")
+	}))
+	defer ts.Close()
+
+	provider := &scalewayProviderImpl{endpoint: ts.URL, client: &http.Client{}}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"id": "a8bd3ef4-ded8-4a6b-a7b5-a9e85e8d9d4b",
+			"name": "scw-instance",
+			"hostname": "scw-instance",
+			"commercial_type": "DEV1-S",
+			"tags": ["web", "env=prod"],
+			"location": {"zone_id": "fr-par-1", "platform_id": "14", "cluster_id": "11"},
+			"public_ip": {"address": "51.15.0.1"}
+		}`)
+	}))
+	defer ts.Close()
+
+	provider := &scalewayProviderImpl{endpoint: ts.URL, client: &http.Client{}}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &InstanceMetadata{
+		ID:             "a8bd3ef4-ded8-4a6b-a7b5-a9e85e8d9d4b",
+		Hostname:       "scw-instance",
+		CommercialType: "DEV1-S",
+		Tags:           []string{"web", "env=prod"},
+		Location:       Location{ZoneID: "fr-par-1"},
+	}, metadata)
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaleway
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is the detector type string
+	TypeStr = "scaleway"
+
+	cloudProviderScaleway = "scaleway"
+	cloudPlatformInstance = "scaleway_instance"
+
+	attributeScalewayTags = "scaleway.tags"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a Scaleway instance metadata detector
+type Detector struct {
+	provider Provider
+	logger   *zap.Logger
+}
+
+// NewDetector creates a new Scaleway instance metadata detector
+func NewDetector(p component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	return &Detector{provider: NewProvider(client), logger: p.Logger}, nil
+}
+
+// Detect detects instance metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	attrs := res.Attributes()
+
+	instance, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("Scaleway detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return res, nil
+	}
+
+	attrs.InsertString(conventions.AttributeCloudProvider, cloudProviderScaleway)
+	attrs.InsertString(conventions.AttributeCloudPlatform, cloudPlatformInstance)
+	attrs.InsertString(conventions.AttributeCloudRegion, region(instance.Location.ZoneID))
+	attrs.InsertString(conventions.AttributeCloudAvailabilityZone, instance.Location.ZoneID)
+	attrs.InsertString(conventions.AttributeHostID, instance.ID)
+	attrs.InsertString(conventions.AttributeHostName, instance.Hostname)
+	attrs.InsertString(conventions.AttributeHostType, instance.CommercialType)
+
+	if len(instance.Tags) > 0 {
+		tags := pdata.NewAttributeValueArray()
+		for _, tag := range instance.Tags {
+			tags.ArrayVal().AppendEmpty().SetStringVal(tag)
+		}
+		attrs.Insert(attributeScalewayTags, tags)
+	}
+
+	return res, nil
+}
+
+// region returns the region of a zone, e.g. fr-par for fr-par-1.
+func region(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		return zone[:i]
+	}
+	return zone
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaleway
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockProvider struct {
+	mock.Mock
+}
+
+func (m *mockProvider) Metadata(context.Context) (*InstanceMetadata, error) {
+	args := m.MethodCalled("Metadata")
+	arg := args.Get(0)
+	var im *InstanceMetadata
+	if arg != nil {
+		im = arg.(*InstanceMetadata)
+	}
+	return im, args.Error(1)
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetectScaleway(t *testing.T) {
+	mp := &mockProvider{}
+	mp.On("Metadata").Return(&InstanceMetadata{
+		ID:             "a8bd3ef4-ded8-4a6b-a7b5-a9e85e8d9d4b",
+		Hostname:       "scw-instance",
+		CommercialType: "DEV1-S",
+		Tags:           []string{"web", "env=prod"},
+		Location:       Location{ZoneID: "fr-par-1"},
+	}, nil)
+
+	detector := &Detector{provider: mp, logger: zap.NewNop()}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	mp.AssertExpectations(t)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":          "scaleway",
+		"cloud.platform":          "scaleway_instance",
+		"cloud.region":            "fr-par",
+		"cloud.availability_zone": "fr-par-1",
+		"host.id":                 "a8bd3ef4-ded8-4a6b-a7b5-a9e85e8d9d4b",
+		"host.name":               "scw-instance",
+		"host.type":               "DEV1-S",
+		"scaleway.tags":           []interface{}{"web", "env=prod"},
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectError(t *testing.T) {
+	mp := &mockProvider{}
+	mp.On("Metadata").Return(nil, errors.New("connection refused"))
+
+	detector := &Detector{provider: mp, logger: zap.NewNop()}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// semconvVersions lists, in release order, the semantic conventions versions detected
+// attributes can be converted to, with the resource attributes renamed in each version.
+// The detectors of this module emit the names of the latest version listed here, so the
+// renames only apply to attribute names supplied by users, e.g. to the env detector.
+var semconvVersions = []struct {
+	version string
+	renames map[string]string
+}{
+	{version: "1.0.0", renames: map[string]string{"cloud.zone": "cloud.availability_zone"}},
+}
+
+// AttributeRenames returns the attribute renames needed to convert detected attributes to the
+// given semantic conventions version. An empty version disables the conversion.
+func AttributeRenames(version string) (map[string]string, error) {
+	if version == "" {
+		return nil, nil
+	}
+
+	renames := map[string]string{}
+	for _, v := range semconvVersions {
+		for from, to := range v.renames {
+			renames[from] = to
+		}
+		if v.version == version {
+			return renames, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported semantic conventions version %q", version)
+}
+
+// renameSources applies the attribute renames to the keys of the attribute sources,
+// in the same way as renameAttributes.
+func renameSources(sources map[string]string, renames map[string]string) {
+	for from, to := range renames {
+		source, ok := sources[from]
+		if !ok {
+			continue
+		}
+		if _, exists := sources[to]; !exists {
+			sources[to] = source
+		}
+		delete(sources, from)
+	}
+}
+
+// renameAttributes applies renames to am. An attribute that already exists under the
+// new name keeps its value, and the old attribute is dropped in either case.
+func renameAttributes(am pdata.AttributeMap, renames map[string]string) {
+	for from, to := range renames {
+		v, ok := am.Get(from)
+		if !ok {
+			continue
+		}
+		am.Insert(to, v)
+		am.Delete(from)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+func TestAttributeRenames(t *testing.T) {
+	renames, err := AttributeRenames("")
+	require.NoError(t, err)
+	assert.Nil(t, renames)
+
+	renames, err = AttributeRenames("1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"cloud.zone": "cloud.availability_zone"}, renames)
+
+	_, err = AttributeRenames("0.5.0")
+	assert.EqualError(t, err, `unsupported semantic conventions version "0.5.0"`)
+}
+
+func TestRenameAttributes(t *testing.T) {
+	renames := map[string]string{"cloud.zone": "cloud.availability_zone"}
+
+	am := NewAttributeMap(map[string]interface{}{"cloud.zone": "us-west-2a", "cloud.region": "us-west-2"})
+	renameAttributes(am, renames)
+	assert.Equal(t, map[string]interface{}{"cloud.availability_zone": "us-west-2a", "cloud.region": "us-west-2"}, AttributesToMap(am))
+
+	am = NewAttributeMap(map[string]interface{}{"cloud.zone": "us-west-2a", "cloud.availability_zone": "us-west-2b"})
+	renameAttributes(am, renames)
+	assert.Equal(t, map[string]interface{}{"cloud.availability_zone": "us-west-2b"}, AttributesToMap(am))
+}
+
+func TestDetectResource_RenamesAttributes(t *testing.T) {
+	md := &MockDetector{}
+	md.On("Detect").Return(NewResource(map[string]interface{}{"cloud.zone": "us-west-2a"}), nil)
+
+	renames, err := AttributeRenames("1.0.0")
+	require.NoError(t, err)
+
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{Timeout: time.Second, AttributeRenames: renames}, requiredDetectors(md)...)
+	got, err := p.Get(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"cloud.availability_zone": "us-west-2a"}, AttributesToMap(got.Attributes()))
+	assert.Equal(t, map[string]string{"cloud.availability_zone": "mockdetector0"}, p.AttributeSources())
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package static provides a detector that adds fixed resource attributes from the configuration.
+package static
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "static"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a detector that returns the attributes set in its configuration
+type Detector struct {
+	attributes pdata.AttributeMap
+}
+
+// NewDetector creates a new static detector
+func NewDetector(_ component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+
+	attrs := pdata.NewAttributeMap()
+	for k, v := range cfg.Attributes {
+		av, err := toAttributeValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for attribute %q: %w", k, err)
+		}
+		attrs.Insert(k, av)
+	}
+	return &Detector{attributes: attrs}, nil
+}
+
+// Detect returns a resource with the configured attributes
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	d.attributes.CopyTo(res.Attributes())
+	return res, nil
+}
+
+func toAttributeValue(v interface{}) (pdata.AttributeValue, error) {
+	switch t := v.(type) {
+	case string:
+		return pdata.NewAttributeValueString(t), nil
+	case bool:
+		return pdata.NewAttributeValueBool(t), nil
+	case int:
+		return pdata.NewAttributeValueInt(int64(t)), nil
+	case int64:
+		return pdata.NewAttributeValueInt(t), nil
+	case float64:
+		return pdata.NewAttributeValueDouble(t), nil
+	case []interface{}:
+		av := pdata.NewAttributeValueArray()
+		for _, elem := range t {
+			ev, err := toAttributeValue(elem)
+			if err != nil {
+				return pdata.AttributeValue{}, err
+			}
+			av.ArrayVal().Append(ev)
+		}
+		return av, nil
+	default:
+		return pdata.AttributeValue{}, fmt.Errorf("unsupported type %T", v)
+	}
+}
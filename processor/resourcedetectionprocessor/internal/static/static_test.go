@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package static
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+func TestDetect(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{
+		Attributes: map[string]interface{}{
+			"deployment.environment": "production",
+			"service.replicas":       3,
+			"sampling.ratio":         0.25,
+			"canary":                 false,
+			"team.owners":            []interface{}{"alice", "bob"},
+		},
+	})
+	require.NoError(t, err)
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"deployment.environment": "production",
+		"service.replicas":       int64(3),
+		"sampling.ratio":         0.25,
+		"canary":                 false,
+		"team.owners":            []interface{}{"alice", "bob"},
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectEmpty(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
+	require.NoError(t, err)
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestNewDetectorUnsupportedType(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{
+		Attributes: map[string]interface{}{"nested": map[string]interface{}{"a": "b"}},
+	})
+	assert.EqualError(t, err, `invalid value for attribute "nested": unsupported type map[string]interface {}`)
+	assert.Nil(t, d)
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+// Config defines user-specified configurations unique to the system detector
+type Config struct {
+	// HostIDSources is the ordered list of sources used to determine host.id. The first
+	// source that succeeds is used. Valid sources are "machine-id", "product_uuid",
+	// "cloud" and "none". If empty, host.id is not set by this detector.
+	HostIDSources []string `mapstructure:"host_id_sources"`
+
+	// ResourceAttributes controls which optional resource attributes are emitted.
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+// ResourceAttributeConfig controls whether a single resource attribute is emitted.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// ResourceAttributesConfig lists the optional resource attributes of the system detector.
+// They are all disabled by default.
+type ResourceAttributesConfig struct {
+	HostIP    ResourceAttributeConfig `mapstructure:"host.ip"`
+	HostMAC   ResourceAttributeConfig `mapstructure:"host.mac"`
+	OSVersion ResourceAttributeConfig `mapstructure:"os.version"`
+	OSBuildID ResourceAttributeConfig `mapstructure:"os.build.id"`
+}
@@ -15,11 +15,15 @@
 package system
 
 import (
+	"fmt"
+	"io/ioutil"
+	"net"
 	"os"
 	"runtime"
 	"strings"
 
 	"github.com/Showmax/go-fqdn"
+	"github.com/shirou/gopsutil/host"
 )
 
 type systemMetadata interface {
@@ -31,10 +35,37 @@ type systemMetadata interface {
 
 	// OSType returns the host operating system
 	OSType() (string, error)
+
+	// OSVersion returns the version of the host operating system
+	OSVersion() (string, error)
+
+	// OSBuildID returns the build identifier of the host operating system
+	OSBuildID() (string, error)
+
+	// HostIPs returns the IP addresses of the host's non-loopback interfaces
+	HostIPs() ([]net.IP, error)
+
+	// HostMACs returns the hardware addresses of the host's non-loopback interfaces
+	HostMACs() ([]net.HardwareAddr, error)
+
+	// MachineID returns the machine ID of the host, as set by systemd or D-Bus
+	MachineID() (string, error)
+
+	// ProductUUID returns the product UUID reported by the host's firmware
+	ProductUUID() (string, error)
+
+	// CloudInstanceID returns the instance ID recorded by cloud-init
+	CloudInstanceID() (string, error)
 }
 
 type systemMetadataImpl struct{}
 
+var (
+	machineIDPaths       = []string{"/etc/machine-id", "/var/lib/dbus/machine-id"}
+	productUUIDPaths     = []string{"/sys/class/dmi/id/product_uuid"}
+	cloudInstanceIDPaths = []string{"/var/lib/cloud/data/instance-id"}
+)
+
 // goosToOSType maps a runtime.GOOS-like value to os.type style.
 func goosToOSType(goos string) string {
 	switch goos {
@@ -55,3 +86,90 @@ func (*systemMetadataImpl) FQDN() (string, error) {
 func (*systemMetadataImpl) Hostname() (string, error) {
 	return os.Hostname()
 }
+
+func (*systemMetadataImpl) OSVersion() (string, error) {
+	info, err := host.Info()
+	if err != nil {
+		return "", err
+	}
+	return info.PlatformVersion, nil
+}
+
+func (*systemMetadataImpl) OSBuildID() (string, error) {
+	info, err := host.Info()
+	if err != nil {
+		return "", err
+	}
+	return info.KernelVersion, nil
+}
+
+func (*systemMetadataImpl) HostIPs() ([]net.IP, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []net.IP
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.IsLoopback() {
+				continue
+			}
+			ips = append(ips, ipNet.IP)
+		}
+	}
+	return ips, nil
+}
+
+func (*systemMetadataImpl) HostMACs() ([]net.HardwareAddr, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var macs []net.HardwareAddr
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 || len(iface.HardwareAddr) == 0 {
+			continue
+		}
+		macs = append(macs, iface.HardwareAddr)
+	}
+	return macs, nil
+}
+
+func (*systemMetadataImpl) MachineID() (string, error) {
+	return readFirstFile(machineIDPaths)
+}
+
+func (*systemMetadataImpl) ProductUUID() (string, error) {
+	return readFirstFile(productUUIDPaths)
+}
+
+func (*systemMetadataImpl) CloudInstanceID() (string, error) {
+	return readFirstFile(cloudInstanceIDPaths)
+}
+
+// readFirstFile returns the trimmed, non-empty content of the first readable file in paths.
+func readFirstFile(paths []string) (string, error) {
+	var lastErr error
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if content := strings.TrimSpace(string(data)); content != "" {
+			return content, nil
+		}
+		lastErr = fmt.Errorf("%s is empty", path)
+	}
+	return "", lastErr
+}
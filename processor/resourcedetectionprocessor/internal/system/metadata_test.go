@@ -15,9 +15,13 @@
 package system
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGOOSToOsType(t *testing.T) {
@@ -26,3 +30,21 @@ func TestGOOSToOsType(t *testing.T) {
 	assert.Equal(t, "WINDOWS", goosToOSType("windows"))
 	assert.Equal(t, "DRAGONFLYBSD", goosToOSType("dragonfly"))
 }
+
+func TestReadFirstFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "system")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, ioutil.WriteFile(empty, []byte("\n"), 0600))
+	machineID := filepath.Join(dir, "machine-id")
+	require.NoError(t, ioutil.WriteFile(machineID, []byte("4b2d7e3c9a8f4e5d\n"), 0600))
+
+	id, err := readFirstFile([]string{filepath.Join(dir, "missing"), empty, machineID})
+	require.NoError(t, err)
+	assert.Equal(t, "4b2d7e3c9a8f4e5d", id)
+
+	_, err = readFirstFile([]string{filepath.Join(dir, "missing"), empty})
+	assert.Error(t, err)
+}
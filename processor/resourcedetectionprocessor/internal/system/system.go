@@ -17,6 +17,8 @@ package system
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
@@ -29,6 +31,16 @@ import (
 const (
 	// TypeStr is the detector type string
 	TypeStr = "system"
+
+	attributeHostIP    = "host.ip"
+	attributeHostMAC   = "host.mac"
+	attributeOSVersion = "os.version"
+	attributeOSBuildID = "os.build.id"
+
+	hostIDSourceMachineID   = "machine-id"
+	hostIDSourceProductUUID = "product_uuid"
+	hostIDSourceCloud       = "cloud"
+	hostIDSourceNone        = "none"
 )
 
 var _ internal.Detector = (*Detector)(nil)
@@ -37,11 +49,20 @@ var _ internal.Detector = (*Detector)(nil)
 type Detector struct {
 	provider systemMetadata
 	logger   *zap.Logger
+	cfg      Config
 }
 
 // NewDetector creates a new system metadata detector
-func NewDetector(p component.ProcessorCreateParams, _ internal.DetectorConfig) (internal.Detector, error) {
-	return &Detector{provider: &systemMetadataImpl{}, logger: p.Logger}, nil
+func NewDetector(p component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	for _, source := range cfg.HostIDSources {
+		switch source {
+		case hostIDSourceMachineID, hostIDSourceProductUUID, hostIDSourceCloud, hostIDSourceNone:
+		default:
+			return nil, fmt.Errorf("invalid host_id_sources entry %q", source)
+		}
+	}
+	return &Detector{provider: &systemMetadataImpl{}, logger: p.Logger, cfg: cfg}, nil
 }
 
 // Detect detects system metadata and returns a resource with the available ones
@@ -67,5 +88,93 @@ func (d *Detector) Detect(_ context.Context) (pdata.Resource, error) {
 	attrs.InsertString(conventions.AttributeHostName, hostname)
 	attrs.InsertString(conventions.AttributeOSType, osType)
 
+	if hostID, ok := d.hostID(); ok {
+		attrs.InsertString(conventions.AttributeHostID, hostID)
+	}
+
+	if err := d.detectOptional(attrs); err != nil {
+		return pdata.NewResource(), err
+	}
+
 	return res, nil
 }
+
+// hostID returns the host ID from the first configured source that provides one.
+func (d *Detector) hostID() (string, bool) {
+	for _, source := range d.cfg.HostIDSources {
+		var hostID string
+		var err error
+		switch source {
+		case hostIDSourceMachineID:
+			hostID, err = d.provider.MachineID()
+		case hostIDSourceProductUUID:
+			hostID, err = d.provider.ProductUUID()
+		case hostIDSourceCloud:
+			hostID, err = d.provider.CloudInstanceID()
+		case hostIDSourceNone:
+			return "", false
+		}
+		if err != nil {
+			d.logger.Debug("failed getting host ID, trying next source", zap.String("source", source), zap.Error(err))
+			continue
+		}
+		return hostID, true
+	}
+
+	if len(d.cfg.HostIDSources) > 0 {
+		d.logger.Warn("none of the configured host ID sources succeeded, host.id is not set", zap.Strings("sources", d.cfg.HostIDSources))
+	}
+	return "", false
+}
+
+// detectOptional adds the resource attributes that have to be enabled in the configuration.
+func (d *Detector) detectOptional(attrs pdata.AttributeMap) error {
+	ras := d.cfg.ResourceAttributes
+
+	if ras.OSVersion.Enabled {
+		osVersion, err := d.provider.OSVersion()
+		if err != nil {
+			return fmt.Errorf("failed getting OS version: %w", err)
+		}
+		attrs.InsertString(attributeOSVersion, osVersion)
+	}
+
+	if ras.OSBuildID.Enabled {
+		buildID, err := d.provider.OSBuildID()
+		if err != nil {
+			return fmt.Errorf("failed getting OS build ID: %w", err)
+		}
+		attrs.InsertString(attributeOSBuildID, buildID)
+	}
+
+	if ras.HostIP.Enabled {
+		ips, err := d.provider.HostIPs()
+		if err != nil {
+			return fmt.Errorf("failed getting host IP addresses: %w", err)
+		}
+		ipVals := pdata.NewAttributeValueArray()
+		for _, ip := range ips {
+			ipVals.ArrayVal().Append(pdata.NewAttributeValueString(ip.String()))
+		}
+		attrs.Insert(attributeHostIP, ipVals)
+	}
+
+	if ras.HostMAC.Enabled {
+		macs, err := d.provider.HostMACs()
+		if err != nil {
+			return fmt.Errorf("failed getting host MAC addresses: %w", err)
+		}
+		macVals := pdata.NewAttributeValueArray()
+		for _, mac := range macs {
+			macVals.ArrayVal().Append(pdata.NewAttributeValueString(toIEEERA(mac)))
+		}
+		attrs.Insert(attributeHostMAC, macVals)
+	}
+
+	return nil
+}
+
+// toIEEERA formats a MAC address in the IEEE RA hexadecimal form, e.g. AC-DE-48-23-45-67.
+func toIEEERA(mac net.HardwareAddr) string {
+	return strings.ToUpper(strings.ReplaceAll(mac.String(), ":", "-"))
+}
@@ -17,6 +17,7 @@ package system
 import (
 	"context"
 	"errors"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -48,12 +49,111 @@ func (m *mockMetadata) OSType() (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+func (m *mockMetadata) OSVersion() (string, error) {
+	args := m.MethodCalled("OSVersion")
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMetadata) OSBuildID() (string, error) {
+	args := m.MethodCalled("OSBuildID")
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMetadata) HostIPs() ([]net.IP, error) {
+	args := m.MethodCalled("HostIPs")
+	return args.Get(0).([]net.IP), args.Error(1)
+}
+
+func (m *mockMetadata) HostMACs() ([]net.HardwareAddr, error) {
+	args := m.MethodCalled("HostMACs")
+	return args.Get(0).([]net.HardwareAddr), args.Error(1)
+}
+
+func (m *mockMetadata) MachineID() (string, error) {
+	args := m.MethodCalled("MachineID")
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMetadata) ProductUUID() (string, error) {
+	args := m.MethodCalled("ProductUUID")
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMetadata) CloudInstanceID() (string, error) {
+	args := m.MethodCalled("CloudInstanceID")
+	return args.String(0), args.Error(1)
+}
+
+func allEnabledConfig() Config {
+	return Config{ResourceAttributes: ResourceAttributesConfig{
+		HostIP:    ResourceAttributeConfig{Enabled: true},
+		HostMAC:   ResourceAttributeConfig{Enabled: true},
+		OSVersion: ResourceAttributeConfig{Enabled: true},
+		OSBuildID: ResourceAttributeConfig{Enabled: true},
+	}}
+}
+
 func TestNewDetector(t *testing.T) {
-	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, nil)
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{})
 	require.NoError(t, err)
 	assert.NotNil(t, d)
 }
 
+func TestNewDetectorInvalidHostIDSource(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateParams{Logger: zap.NewNop()}, Config{HostIDSources: []string{"machine-id", "bios"}})
+	assert.EqualError(t, err, `invalid host_id_sources entry "bios"`)
+	assert.Nil(t, d)
+}
+
+func TestDetectHostID(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []string
+		want    interface{}
+	}{
+		{
+			name:    "first source fails",
+			sources: []string{"product_uuid", "machine-id"},
+			want:    "machine-id-value",
+		},
+		{
+			name:    "cloud",
+			sources: []string{"cloud", "machine-id"},
+			want:    "i-abcd1234",
+		},
+		{
+			name:    "none stops the lookup",
+			sources: []string{"product_uuid", "none", "machine-id"},
+		},
+		{
+			name:    "all sources fail",
+			sources: []string{"product_uuid"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := &mockMetadata{}
+			md.On("FQDN").Return("fqdn", nil)
+			md.On("OSType").Return("LINUX", nil)
+			md.On("MachineID").Return("machine-id-value", nil)
+			md.On("ProductUUID").Return("", errors.New("permission denied"))
+			md.On("CloudInstanceID").Return("i-abcd1234", nil)
+
+			detector := &Detector{provider: md, logger: zap.NewNop(), cfg: Config{HostIDSources: tt.sources}}
+			res, err := detector.Detect(context.Background())
+			require.NoError(t, err)
+
+			hostID, ok := res.Attributes().Get(conventions.AttributeHostID)
+			if tt.want == nil {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.want, hostID.StringVal())
+		})
+	}
+}
+
 func TestDetectFQDNAvailable(t *testing.T) {
 	md := &mockMetadata{}
 	md.On("FQDN").Return("fqdn", nil)
@@ -75,6 +175,45 @@ func TestDetectFQDNAvailable(t *testing.T) {
 
 }
 
+func TestDetectOptionalAttributes(t *testing.T) {
+	mac, err := net.ParseMAC("ac:de:48:23:45:67")
+	require.NoError(t, err)
+
+	md := &mockMetadata{}
+	md.On("FQDN").Return("fqdn", nil)
+	md.On("OSType").Return("LINUX", nil)
+	md.On("OSVersion").Return("20.04", nil)
+	md.On("OSBuildID").Return("5.4.0-72-generic", nil)
+	md.On("HostIPs").Return([]net.IP{net.ParseIP("192.168.1.140"), net.ParseIP("fe80::abc2:4a28:737a:609e")}, nil)
+	md.On("HostMACs").Return([]net.HardwareAddr{mac}, nil)
+
+	detector := &Detector{provider: md, logger: zap.NewNop(), cfg: allEnabledConfig()}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	md.AssertExpectations(t)
+
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeHostName: "fqdn",
+		conventions.AttributeOSType:   "LINUX",
+		"os.version":                  "20.04",
+		"os.build.id":                 "5.4.0-72-generic",
+		"host.ip":                     []interface{}{"192.168.1.140", "fe80::abc2:4a28:737a:609e"},
+		"host.mac":                    []interface{}{"AC-DE-48-23-45-67"},
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectOptionalAttributesError(t *testing.T) {
+	md := &mockMetadata{}
+	md.On("FQDN").Return("fqdn", nil)
+	md.On("OSType").Return("LINUX", nil)
+	md.On("OSVersion").Return("", errors.New("err"))
+
+	detector := &Detector{provider: md, logger: zap.NewNop(), cfg: allEnabledConfig()}
+	res, err := detector.Detect(context.Background())
+	assert.EqualError(t, err, "failed getting OS version: err")
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
 func TestFallbackHostname(t *testing.T) {
 	mdHostname := &mockMetadata{}
 	mdHostname.On("Hostname").Return("hostname", nil)
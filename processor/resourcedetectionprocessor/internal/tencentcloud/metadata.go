@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tencentcloud
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	// CVM instance metadata endpoint, see https://www.tencentcloud.com/document/product/213/4934
+	metadataEndpoint = "http://metadata.tencentyun.com/latest/meta-data/"
+)
+
+// Provider gets metadata from the Tencent Cloud CVM metadata service
+type Provider interface {
+	Metadata(context.Context) (*InstanceMetadata, error)
+}
+
+type cvmProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider that uses the given HTTP client,
+// or a default client if it is nil
+func NewProvider(client *http.Client) Provider {
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &cvmProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   client,
+	}
+}
+
+// InstanceMetadata is the CVM instance metadata used by the detector
+type InstanceMetadata struct {
+	InstanceID   string
+	InstanceType string
+	Region       string
+	Zone         string
+}
+
+// Metadata queries the CVM metadata service. Unlike most metadata services, it serves each
+// value as plain text on its own path, so one request is sent per value.
+func (p *cvmProviderImpl) Metadata(ctx context.Context) (*InstanceMetadata, error) {
+	var metadata InstanceMetadata
+	for path, value := range map[string]*string{
+		"instance-id":            &metadata.InstanceID,
+		"instance/instance-type": &metadata.InstanceType,
+		"placement/region":       &metadata.Region,
+		"placement/zone":         &metadata.Zone,
+	} {
+		v, err := p.get(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		*value = v
+	}
+	return &metadata, nil
+}
+
+func (p *cvmProviderImpl) get(ctx context.Context, path string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query CVM metadata service: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		//lint:ignore ST1005 CVM is an acronym here
+		return "", fmt.Errorf("CVM metadata service replied with status code %s for %s", resp.Status, path)
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read CVM metadata service reply: %v", err)
+	}
+
+	return strings.TrimSpace(string(respBody)), nil
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tencentcloud
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is the detector type string
+	TypeStr = "tencentcloud_cvm"
+
+	cloudProviderTencentCloud = "tencent_cloud"
+	cloudPlatformCVM          = "tencent_cloud_cvm"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a Tencent Cloud CVM metadata detector
+type Detector struct {
+	provider Provider
+	logger   *zap.Logger
+}
+
+// NewDetector creates a new Tencent Cloud CVM metadata detector
+func NewDetector(p component.ProcessorCreateParams, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	return &Detector{provider: NewProvider(client), logger: p.Logger}, nil
+}
+
+// Detect detects CVM instance metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	attrs := res.Attributes()
+
+	instance, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("Tencent Cloud CVM detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return res, nil
+	}
+
+	attrs.InsertString(conventions.AttributeCloudProvider, cloudProviderTencentCloud)
+	attrs.InsertString(conventions.AttributeCloudPlatform, cloudPlatformCVM)
+	attrs.InsertString(conventions.AttributeCloudRegion, instance.Region)
+	attrs.InsertString(conventions.AttributeCloudAvailabilityZone, instance.Zone)
+	attrs.InsertString(conventions.AttributeHostID, instance.InstanceID)
+	attrs.InsertString(conventions.AttributeHostType, instance.InstanceType)
+
+	return res, nil
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// TransformAction is the transformation applied to the value of an attribute.
+type TransformAction string
+
+const (
+	// TransformHash replaces the value with its hex-encoded SHA-256 hash.
+	TransformHash TransformAction = "hash"
+	// TransformTruncate keeps only the first Length characters of the value.
+	TransformTruncate TransformAction = "truncate"
+	// TransformRedact replaces the value with a fixed placeholder.
+	TransformRedact TransformAction = "redact"
+
+	redactedValue = "<redacted>"
+)
+
+// AttributeTransform is a transformation applied to a detected attribute, e.g. to avoid
+// exporting raw host names while keeping a stable identifier.
+type AttributeTransform struct {
+	// Key is the key of the attribute to transform.
+	Key string `mapstructure:"key"`
+	// Action is the transformation to apply, one of "hash", "truncate" or "redact".
+	Action TransformAction `mapstructure:"action"`
+	// Length is the number of characters kept by the "truncate" action.
+	Length int `mapstructure:"length"`
+}
+
+// ValidateAttributeTransforms returns an error if any of the transforms is invalid.
+func ValidateAttributeTransforms(transforms []AttributeTransform) error {
+	for _, t := range transforms {
+		if t.Key == "" {
+			return fmt.Errorf("attribute transform with action %q is missing a key", t.Action)
+		}
+		switch t.Action {
+		case TransformHash, TransformRedact:
+		case TransformTruncate:
+			if t.Length <= 0 {
+				return fmt.Errorf("truncate transform of attribute %q requires a positive length", t.Key)
+			}
+		default:
+			return fmt.Errorf("invalid action %q for attribute %q, must be one of %q, %q or %q", t.Action, t.Key, TransformHash, TransformTruncate, TransformRedact)
+		}
+	}
+	return nil
+}
+
+// applyTransforms applies the transforms to the attributes they refer to, in order.
+// Non-string values are transformed as their string representation.
+func applyTransforms(am pdata.AttributeMap, transforms []AttributeTransform) {
+	for _, t := range transforms {
+		v, ok := am.Get(t.Key)
+		if !ok {
+			continue
+		}
+
+		value := v.StringVal()
+		if v.Type() != pdata.AttributeValueSTRING {
+			value = fmt.Sprint(UnwrapAttribute(v))
+		}
+
+		switch t.Action {
+		case TransformHash:
+			sum := sha256.Sum256([]byte(value))
+			value = hex.EncodeToString(sum[:])
+		case TransformTruncate:
+			if runes := []rune(value); len(runes) > t.Length {
+				value = string(runes[:t.Length])
+			}
+		case TransformRedact:
+			value = redactedValue
+		}
+		am.UpsertString(t.Key, value)
+	}
+}
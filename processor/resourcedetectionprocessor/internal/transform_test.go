@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+func TestValidateAttributeTransforms(t *testing.T) {
+	assert.NoError(t, ValidateAttributeTransforms(nil))
+	assert.NoError(t, ValidateAttributeTransforms([]AttributeTransform{
+		{Key: "host.name", Action: TransformHash},
+		{Key: "host.id", Action: TransformTruncate, Length: 8},
+		{Key: "host.ip", Action: TransformRedact},
+	}))
+
+	assert.EqualError(t, ValidateAttributeTransforms([]AttributeTransform{{Action: TransformHash}}),
+		`attribute transform with action "hash" is missing a key`)
+	assert.EqualError(t, ValidateAttributeTransforms([]AttributeTransform{{Key: "host.id", Action: TransformTruncate}}),
+		`truncate transform of attribute "host.id" requires a positive length`)
+	assert.EqualError(t, ValidateAttributeTransforms([]AttributeTransform{{Key: "host.id", Action: "encrypt"}}),
+		`invalid action "encrypt" for attribute "host.id", must be one of "hash", "truncate" or "redact"`)
+}
+
+func TestApplyTransforms(t *testing.T) {
+	am := NewAttributeMap(map[string]interface{}{
+		"host.name": "my-host",
+		"host.id":   "i-0123456789abcdef",
+		"host.ip":   "10.0.0.1",
+		"port":      int64(8080),
+		"other":     "unchanged",
+	})
+	applyTransforms(am, []AttributeTransform{
+		{Key: "host.name", Action: TransformHash},
+		{Key: "host.id", Action: TransformTruncate, Length: 6},
+		{Key: "host.ip", Action: TransformRedact},
+		{Key: "port", Action: TransformHash},
+		{Key: "missing", Action: TransformRedact},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"host.name": "e6ad3b2dfe7dd8e972ef383f5d592f3016ae0313463eddf311968a48d8a4770f",
+		"host.id":   "i-0123",
+		"host.ip":   "<redacted>",
+		"port":      "6c237681e70921603a306be9a1a5d9833fce5c1e268f52b1650970eaad0dce21",
+		"other":     "unchanged",
+	}, AttributesToMap(am))
+}
+
+func TestDetectResource_TransformsAttributes(t *testing.T) {
+	md := &MockDetector{}
+	md.On("Detect").Return(NewResource(map[string]interface{}{"host.name": "my-host"}), nil)
+
+	p := NewResourceProvider(zap.NewNop(), ProviderSettings{
+		Timeout:             time.Second,
+		AttributeTransforms: []AttributeTransform{{Key: "host.name", Action: TransformHash}},
+	}, requiredDetectors(md)...)
+	got, err := p.Get(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"host.name": "e6ad3b2dfe7dd8e972ef383f5d592f3016ae0313463eddf311968a48d8a4770f"}, AttributesToMap(got.Attributes()))
+}
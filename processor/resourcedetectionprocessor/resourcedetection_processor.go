@@ -16,52 +16,182 @@ package resourcedetectionprocessor
 
 import (
 	"context"
+	"sync"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/podassociation"
 )
 
 type resourceDetectionProcessor struct {
-	provider *internal.ResourceProvider
-	resource pdata.Resource
-	override bool
+	logger             *zap.Logger
+	provider           *internal.ResourceProvider
+	debug              *debugServer
+	override           bool
+	overrideAttributes map[string]struct{}
+
+	// detectOnFirstBatch defers detection from Start to the first batch of data, using host.
+	detectOnFirstBatch bool
+	host               component.Host
+	failureLogOnce     sync.Once
+
+	// onError is the behavior if detection fails, one of onErrorFail, onErrorIgnore or onErrorRetryInBackground.
+	onError string
+
+	// podResolver resolves the pod that sent a batch of data from the client IP, if pod association is enabled.
+	podResolver podResolver
+
+	// entityEmitter emits the detected resource as entity state events, if enabled in a logs pipeline.
+	entityEmitter *entityEmitter
 }
 
+// podResolver resolves client IPs to the resource attributes of their pods, see podassociation.Resolver.
+type podResolver interface {
+	Start()
+	Shutdown()
+	Resource(ip string) (pdata.Resource, bool)
+}
+
+var _ podResolver = (*podassociation.Resolver)(nil)
+
 // Start is invoked during service startup.
-func (rdp *resourceDetectionProcessor) Start(ctx context.Context, _ component.Host) error {
-	var err error
-	rdp.resource, err = rdp.provider.Get(ctx)
-	return err
+func (rdp *resourceDetectionProcessor) Start(ctx context.Context, host component.Host) error {
+	if rdp.debug != nil {
+		if err := rdp.debug.start(); err != nil {
+			return err
+		}
+	}
+	if rdp.podResolver != nil {
+		rdp.podResolver.Start()
+	}
+
+	if rdp.detectOnFirstBatch {
+		rdp.host = host
+		rdp.startEntityEmitter()
+		return nil
+	}
+	_, err := rdp.provider.Get(ctx, host)
+	if err != nil && rdp.onError == onErrorFail {
+		return err
+	}
+	if err != nil {
+		rdp.handleDetectionFailure(err)
+	}
+	rdp.startEntityEmitter()
+	return nil
+}
+
+// startEntityEmitter starts emitting entity state events, if enabled. Events are only emitted
+// once the resource is detected, so this does not wait for a successful detection.
+func (rdp *resourceDetectionProcessor) startEntityEmitter() {
+	if rdp.entityEmitter != nil {
+		rdp.entityEmitter.start()
+	}
+}
+
+// handleDetectionFailure logs a failed detection after which the data is passed through unchanged,
+// and with onErrorRetryInBackground, starts retrying the detection.
+func (rdp *resourceDetectionProcessor) handleDetectionFailure(err error) {
+	if rdp.onError == onErrorRetryInBackground {
+		rdp.logger.Error("failed detecting resource information, passing data through unchanged until a retry succeeds", zap.Error(err))
+		rdp.provider.RetryInBackground()
+		return
+	}
+	rdp.logger.Error("failed detecting resource information, passing data through unchanged", zap.Error(err))
+}
+
+// detectedResource returns the resource to merge into the processed data. With detectOnFirstBatch,
+// the first call runs the detection; if it fails, the data is passed through unchanged, whatever the
+// onError behavior, until a detection succeeds.
+func (rdp *resourceDetectionProcessor) detectedResource() pdata.Resource {
+	if !rdp.detectOnFirstBatch {
+		return rdp.provider.Resource()
+	}
+
+	// detection must not be bound to the context of the batch that happens to trigger it
+	res, err := rdp.provider.Get(context.Background(), rdp.host)
+	if err != nil {
+		rdp.failureLogOnce.Do(func() {
+			rdp.handleDetectionFailure(err)
+		})
+		return pdata.NewResource()
+	}
+	return res
+}
+
+// batchResource returns the resource to merge into a batch of data received in ctx. With pod
+// association, the attributes of the pod that sent the batch take precedence over the detected ones.
+func (rdp *resourceDetectionProcessor) batchResource(ctx context.Context) pdata.Resource {
+	detected := rdp.detectedResource()
+	if rdp.podResolver == nil {
+		return detected
+	}
+
+	c, ok := client.FromContext(ctx)
+	if !ok {
+		return detected
+	}
+	res, ok := rdp.podResolver.Resource(c.IP)
+	if !ok {
+		return detected
+	}
+
+	attr := res.Attributes()
+	detected.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+		attr.Insert(k, v)
+		return true
+	})
+	return res
+}
+
+// Shutdown is invoked during service shutdown.
+func (rdp *resourceDetectionProcessor) Shutdown(ctx context.Context) error {
+	if rdp.entityEmitter != nil {
+		rdp.entityEmitter.shutdown()
+	}
+	rdp.provider.Shutdown()
+	if rdp.podResolver != nil {
+		rdp.podResolver.Shutdown()
+	}
+	if rdp.debug != nil {
+		return rdp.debug.shutdown(ctx)
+	}
+	return nil
 }
 
 // ProcessTraces implements the TracesProcessor interface
-func (rdp *resourceDetectionProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+func (rdp *resourceDetectionProcessor) ProcessTraces(ctx context.Context, td pdata.Traces) (pdata.Traces, error) {
+	detected := rdp.batchResource(ctx)
 	rs := td.ResourceSpans()
 	for i := 0; i < rs.Len(); i++ {
 		res := rs.At(i).Resource()
-		internal.MergeResource(res, rdp.resource, rdp.override)
+		internal.MergeResourceWithOverrides(res, detected, rdp.override, rdp.overrideAttributes)
 	}
 	return td, nil
 }
 
 // ProcessMetrics implements the MetricsProcessor interface
-func (rdp *resourceDetectionProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+func (rdp *resourceDetectionProcessor) ProcessMetrics(ctx context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	detected := rdp.batchResource(ctx)
 	rm := md.ResourceMetrics()
 	for i := 0; i < rm.Len(); i++ {
 		res := rm.At(i).Resource()
-		internal.MergeResource(res, rdp.resource, rdp.override)
+		internal.MergeResourceWithOverrides(res, detected, rdp.override, rdp.overrideAttributes)
 	}
 	return md, nil
 }
 
 // ProcessLogs implements the LogsProcessor interface
-func (rdp *resourceDetectionProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+func (rdp *resourceDetectionProcessor) ProcessLogs(ctx context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	detected := rdp.batchResource(ctx)
 	rls := ld.ResourceLogs()
 	for i := 0; i < rls.Len(); i++ {
 		res := rls.At(i).Resource()
-		internal.MergeResource(res, rdp.resource, rdp.override)
+		internal.MergeResourceWithOverrides(res, detected, rdp.override, rdp.overrideAttributes)
 	}
 	return ld, nil
 }
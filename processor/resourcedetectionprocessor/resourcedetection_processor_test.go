@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
@@ -51,6 +52,7 @@ func TestResourceProcessor(t *testing.T) {
 		name               string
 		detectorKeys       []string
 		override           bool
+		overrideAttributes []string
 		sourceResource     pdata.Resource
 		detectedResource   pdata.Resource
 		detectedError      error
@@ -106,6 +108,25 @@ func TestResourceProcessor(t *testing.T) {
 				"host.name":               "k8s-node",
 			}),
 		},
+		{
+			name:               "Selected attributes are overridden",
+			override:           false,
+			overrideAttributes: []string{"host.name"},
+			sourceResource: internal.NewResource(map[string]interface{}{
+				"host.name":               "will-be-overridden",
+				"cloud.availability_zone": "original-zone",
+			}),
+			detectedResource: internal.NewResource(map[string]interface{}{
+				"host.name":               "k8s-node",
+				"cloud.availability_zone": "will-be-ignored",
+				"k8s.cluster.name":        "k8s-cluster",
+			}),
+			expectedResource: internal.NewResource(map[string]interface{}{
+				"host.name":               "k8s-node",
+				"cloud.availability_zone": "original-zone",
+				"k8s.cluster.name":        "k8s-cluster",
+			}),
+		},
 		{
 			name: "Empty detected resource",
 			sourceResource: internal.NewResource(map[string]interface{}{
@@ -146,7 +167,7 @@ func TestResourceProcessor(t *testing.T) {
 				"cloud.availability_zone": "original-zone",
 			}),
 			detectedError:      errors.New("err1"),
-			expectedStartError: "err1",
+			expectedStartError: `failed detecting resource with detector type "mock": err1`,
 		},
 		{
 			name:             "Invalid detector key",
@@ -171,10 +192,11 @@ func TestResourceProcessor(t *testing.T) {
 			}
 
 			cfg := &Config{
-				ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
-				Override:          tt.override,
-				Detectors:         tt.detectorKeys,
-				Timeout:           time.Second,
+				ProcessorSettings:  config.NewProcessorSettings(config.NewID(typeStr)),
+				Override:           tt.override,
+				OverrideAttributes: tt.overrideAttributes,
+				Detectors:          tt.detectorKeys,
+				Timeout:            time.Second,
 			}
 
 			// Test trace consuner
@@ -279,6 +301,141 @@ func TestResourceProcessor(t *testing.T) {
 	}
 }
 
+func newDetectOnFirstBatchProcessor(t *testing.T, md *MockDetector, sink *consumertest.TracesSink) component.TracesProcessor {
+	factory := &factory{providers: map[config.ComponentID]*internal.ResourceProvider{}}
+	factory.resourceProviderFactory = internal.NewProviderFactory(
+		map[internal.DetectorType]internal.DetectorFactory{"mock": func(component.ProcessorCreateParams, internal.DetectorConfig) (internal.Detector, error) {
+			return md, nil
+		}})
+
+	cfg := &Config{
+		ProcessorSettings:  config.NewProcessorSettings(config.NewID(typeStr)),
+		Detectors:          []string{"mock"},
+		Timeout:            time.Second,
+		DetectOnFirstBatch: true,
+	}
+	tp, err := factory.createTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, sink)
+	require.NoError(t, err)
+	return tp
+}
+
+func TestResourceProcessorDetectOnFirstBatch(t *testing.T) {
+	md := &MockDetector{}
+	md.On("Detect").Return(internal.NewResource(map[string]interface{}{"host.name": "node"}), nil).Once()
+
+	sink := new(consumertest.TracesSink)
+	tp := newDetectOnFirstBatchProcessor(t, md, sink)
+
+	require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, tp.Shutdown(context.Background())) }()
+	md.AssertNotCalled(t, "Detect")
+
+	for i := 0; i < 2; i++ {
+		td := pdata.NewTraces()
+		td.ResourceSpans().AppendEmpty()
+		require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	}
+	md.AssertNumberOfCalls(t, "Detect", 1)
+
+	for _, td := range sink.AllTraces() {
+		assert.Equal(t, map[string]interface{}{"host.name": "node"}, internal.AttributesToMap(td.ResourceSpans().At(0).Resource().Attributes()))
+	}
+}
+
+func TestResourceProcessorDetectOnFirstBatchError(t *testing.T) {
+	md := &MockDetector{}
+	md.On("Detect").Return(pdata.NewResource(), errors.New("err1"))
+
+	sink := new(consumertest.TracesSink)
+	tp := newDetectOnFirstBatchProcessor(t, md, sink)
+
+	require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, tp.Shutdown(context.Background())) }()
+
+	td := pdata.NewTraces()
+	internal.NewResource(map[string]interface{}{"host.name": "original"}).CopyTo(td.ResourceSpans().AppendEmpty().Resource())
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+	got := sink.AllTraces()[0].ResourceSpans().At(0).Resource()
+	assert.Equal(t, map[string]interface{}{"host.name": "original"}, internal.AttributesToMap(got.Attributes()))
+}
+
+func TestResourceProcessorOnError(t *testing.T) {
+	for _, onError := range []string{onErrorIgnore, onErrorRetryInBackground} {
+		t.Run(onError, func(t *testing.T) {
+			md := &MockDetector{}
+			md.On("Detect").Return(pdata.NewResource(), errors.New("err1"))
+
+			factory := &factory{providers: map[config.ComponentID]*internal.ResourceProvider{}}
+			factory.resourceProviderFactory = internal.NewProviderFactory(
+				map[internal.DetectorType]internal.DetectorFactory{"mock": func(component.ProcessorCreateParams, internal.DetectorConfig) (internal.Detector, error) {
+					return md, nil
+				}})
+
+			cfg := &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+				Detectors:         []string{"mock"},
+				Timeout:           time.Second,
+				OnError:           onError,
+			}
+			sink := new(consumertest.TracesSink)
+			tp, err := factory.createTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, sink)
+			require.NoError(t, err)
+
+			require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() { assert.NoError(t, tp.Shutdown(context.Background())) }()
+
+			td := pdata.NewTraces()
+			internal.NewResource(map[string]interface{}{"host.name": "original"}).CopyTo(td.ResourceSpans().AppendEmpty().Resource())
+			require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+			got := sink.AllTraces()[0].ResourceSpans().At(0).Resource()
+			assert.Equal(t, map[string]interface{}{"host.name": "original"}, internal.AttributesToMap(got.Attributes()))
+		})
+	}
+}
+
+type fakePodResolver map[string]pdata.Resource
+
+func (fakePodResolver) Start()    {}
+func (fakePodResolver) Shutdown() {}
+
+func (r fakePodResolver) Resource(ip string) (pdata.Resource, bool) {
+	res, ok := r[ip]
+	return res, ok
+}
+
+func TestResourceProcessorPodAssociation(t *testing.T) {
+	md := &MockDetector{}
+	md.On("Detect").Return(internal.NewResource(map[string]interface{}{
+		"cloud.region":  "us-east-1",
+		"k8s.node.name": "gateway-node",
+	}), nil)
+
+	rdp := &resourceDetectionProcessor{
+		logger:   zap.NewNop(),
+		provider: internal.NewResourceProvider(zap.NewNop(), internal.ProviderSettings{Timeout: time.Second}, internal.ConfiguredDetector{Type: "mock", Detector: md}),
+		podResolver: fakePodResolver{"10.0.0.1": internal.NewResource(map[string]interface{}{
+			"k8s.pod.name":  "app",
+			"k8s.node.name": "node-1",
+		})},
+	}
+	require.NoError(t, rdp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, rdp.Shutdown(context.Background())) }()
+
+	for ip, expected := range map[string]map[string]interface{}{
+		"10.0.0.1": {"cloud.region": "us-east-1", "k8s.pod.name": "app", "k8s.node.name": "node-1"},
+		"10.0.0.2": {"cloud.region": "us-east-1", "k8s.node.name": "gateway-node"},
+	} {
+		td := pdata.NewTraces()
+		td.ResourceSpans().AppendEmpty()
+		ctx := client.NewContext(context.Background(), &client.Client{IP: ip})
+		td, err := rdp.ProcessTraces(ctx, td)
+		require.NoError(t, err)
+		assert.Equal(t, expected, internal.AttributesToMap(td.ResourceSpans().At(0).Resource().Attributes()))
+	}
+}
+
 func oCensusResource(res pdata.Resource) *resourcepb.Resource {
 	if res.Attributes().Len() == 0 {
 		return &resourcepb.Resource{}
@@ -0,0 +1,209 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	attributeType = "Type"
+
+	// overflowValue is the namespace, pod and container name of the resources the metrics of the
+	// pods and containers beyond Config.MaxPodSeries are aggregated into.
+	overflowValue = "other"
+)
+
+// overflowAttributes are the resource attributes that are kept on the overflow resources, the
+// others identify a single pod or container.
+var overflowAttributes = []string{attributeType, attributeNodeName, "ClusterName", "InstanceId", "InstanceType", "AutoScalingGroupName"}
+
+// overflowBucket is an overflow resource, which aggregates the metrics of a resource type.
+type overflowBucket struct {
+	metrics pdata.MetricSlice
+	// index maps the metric names to their position in metrics
+	index map[string]int
+}
+
+// limitSeries keeps the metrics of the first limit pods and containers of mds, the metrics a provider generated
+// in a collection, and aggregates the metrics of the others into overflow resources per resource type, which
+// are returned in an extra pdata.Metrics.
+// It returns the resulting metrics and the number of aggregated resources.
+func limitSeries(mds []pdata.Metrics, limit int) ([]pdata.Metrics, int) {
+	series := map[string]bool{}
+	overflow := pdata.NewMetrics()
+	buckets := map[string]*overflowBucket{}
+	aggregated := 0
+
+	for _, md := range mds {
+		md.ResourceMetrics().RemoveIf(func(rm pdata.ResourceMetrics) bool {
+			key, ok := seriesKey(rm.Resource().Attributes())
+			if !ok || series[key] {
+				return false
+			}
+			if len(series) < limit {
+				series[key] = true
+				return false
+			}
+
+			aggregated++
+			bucket := overflowBucketFor(overflow, buckets, rm.Resource().Attributes())
+			ilms := rm.InstrumentationLibraryMetrics()
+			for i := 0; i < ilms.Len(); i++ {
+				metrics := ilms.At(i).Metrics()
+				for j := 0; j < metrics.Len(); j++ {
+					bucket.aggregate(metrics.At(j))
+				}
+			}
+			return true
+		})
+	}
+
+	if aggregated == 0 {
+		return mds, 0
+	}
+	return append(mds, overflow), aggregated
+}
+
+// seriesKey returns the key identifying the pod or container of a resource, and false for the other resources.
+func seriesKey(attrs pdata.AttributeMap) (string, bool) {
+	pod, ok := attrs.Get(attributePodName)
+	if !ok {
+		return "", false
+	}
+
+	key := pod.StringVal()
+	if namespace, ok := attrs.Get(attributeNamespace); ok {
+		key = namespace.StringVal() + "/" + key
+	}
+	if container, ok := attrs.Get(attributeContainerName); ok {
+		key += "/" + container.StringVal()
+	}
+	return key, true
+}
+
+func overflowBucketFor(overflow pdata.Metrics, buckets map[string]*overflowBucket, attrs pdata.AttributeMap) *overflowBucket {
+	var typ string
+	if v, ok := attrs.Get(attributeType); ok {
+		typ = v.StringVal()
+	}
+	if bucket, ok := buckets[typ]; ok {
+		return bucket
+	}
+
+	rm := overflow.ResourceMetrics().AppendEmpty()
+	overflowAttrs := rm.Resource().Attributes()
+	for _, k := range overflowAttributes {
+		if v, ok := attrs.Get(k); ok {
+			overflowAttrs.Insert(k, v)
+		}
+	}
+	for _, k := range []string{attributeNamespace, attributePodName, attributeContainerName} {
+		if _, ok := attrs.Get(k); ok {
+			overflowAttrs.InsertString(k, overflowValue)
+		}
+	}
+
+	bucket := &overflowBucket{
+		metrics: rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics(),
+		index:   map[string]int{},
+	}
+	buckets[typ] = bucket
+	return bucket
+}
+
+// aggregate adds the data points of m to the metric of the same name in the bucket. Only gauges and
+// sums are aggregated, by summing the data points with the same labels; the other metrics are dropped.
+func (b *overflowBucket) aggregate(m pdata.Metric) {
+	i, ok := b.index[m.Name()]
+	if !ok {
+		switch m.DataType() {
+		case pdata.MetricDataTypeIntGauge, pdata.MetricDataTypeDoubleGauge, pdata.MetricDataTypeIntSum, pdata.MetricDataTypeDoubleSum:
+			b.index[m.Name()] = b.metrics.Len()
+			m.CopyTo(b.metrics.AppendEmpty())
+		}
+		return
+	}
+
+	dest := b.metrics.At(i)
+	if dest.DataType() != m.DataType() {
+		return
+	}
+	switch m.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		addIntDataPoints(dest.IntGauge().DataPoints(), m.IntGauge().DataPoints())
+	case pdata.MetricDataTypeDoubleGauge:
+		addDoubleDataPoints(dest.DoubleGauge().DataPoints(), m.DoubleGauge().DataPoints())
+	case pdata.MetricDataTypeIntSum:
+		addIntDataPoints(dest.IntSum().DataPoints(), m.IntSum().DataPoints())
+	case pdata.MetricDataTypeDoubleSum:
+		addDoubleDataPoints(dest.DoubleSum().DataPoints(), m.DoubleSum().DataPoints())
+	}
+}
+
+func addIntDataPoints(dest, src pdata.IntDataPointSlice) {
+	for i := 0; i < src.Len(); i++ {
+		p := src.At(i)
+		found := false
+		for j := 0; j < dest.Len(); j++ {
+			d := dest.At(j)
+			if labelsKey(d.LabelsMap()) == labelsKey(p.LabelsMap()) {
+				d.SetValue(d.Value() + p.Value())
+				if p.Timestamp() > d.Timestamp() {
+					d.SetTimestamp(p.Timestamp())
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			p.CopyTo(dest.AppendEmpty())
+		}
+	}
+}
+
+func addDoubleDataPoints(dest, src pdata.DoubleDataPointSlice) {
+	for i := 0; i < src.Len(); i++ {
+		p := src.At(i)
+		found := false
+		for j := 0; j < dest.Len(); j++ {
+			d := dest.At(j)
+			if labelsKey(d.LabelsMap()) == labelsKey(p.LabelsMap()) {
+				d.SetValue(d.Value() + p.Value())
+				if p.Timestamp() > d.Timestamp() {
+					d.SetTimestamp(p.Timestamp())
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			p.CopyTo(dest.AppendEmpty())
+		}
+	}
+}
+
+func labelsKey(labels pdata.StringMap) string {
+	pairs := make([]string, 0, labels.Len())
+	labels.Range(func(k, v string) bool {
+		pairs = append(pairs, k+"="+v)
+		return true
+	})
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,123 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func newPodResourceMetrics(md pdata.Metrics, pod string, cpu float64, restarts int64) {
+	newResourceMetrics(md, map[string]string{
+		attributeType:      "Pod",
+		attributeNodeName:  "node-1",
+		attributeNamespace: "default",
+		attributePodName:   pod,
+		"PodId":            pod + "-id",
+	})
+	rm := md.ResourceMetrics().At(md.ResourceMetrics().Len() - 1)
+	metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	m := metrics.AppendEmpty()
+	m.SetName("pod_cpu_utilization")
+	m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	m.DoubleGauge().DataPoints().AppendEmpty().SetValue(cpu)
+
+	m = metrics.AppendEmpty()
+	m.SetName("pod_number_of_container_restarts")
+	m.SetDataType(pdata.MetricDataTypeIntGauge)
+	p := m.IntGauge().DataPoints().AppendEmpty()
+	p.LabelsMap().Insert("reason", "error")
+	p.SetValue(restarts)
+}
+
+func TestLimitSeries(t *testing.T) {
+	md1 := pdata.NewMetrics()
+	newResourceMetrics(md1, map[string]string{attributeType: "Node", attributeNodeName: "node-1"})
+	newPodResourceMetrics(md1, "a", 1, 1)
+	newPodResourceMetrics(md1, "b", 2, 2)
+	md2 := pdata.NewMetrics()
+	newPodResourceMetrics(md2, "c", 3, 3)
+	newPodResourceMetrics(md2, "a", 4, 4)
+	newPodResourceMetrics(md2, "d", 5, 5)
+
+	mds, aggregated := limitSeries([]pdata.Metrics{md1, md2}, 2)
+	assert.Equal(t, 2, aggregated)
+	require.Len(t, mds, 3)
+	assert.Equal(t, 3, mds[0].ResourceMetrics().Len())
+	assert.Equal(t, 1, mds[1].ResourceMetrics().Len())
+
+	overflow := mds[2].ResourceMetrics()
+	require.Equal(t, 1, overflow.Len())
+	assert.Equal(t, map[string]interface{}{
+		attributeType:      "Pod",
+		attributeNodeName:  "node-1",
+		attributeNamespace: overflowValue,
+		attributePodName:   overflowValue,
+	}, attributesToMap(overflow.At(0).Resource().Attributes()))
+
+	metrics := overflow.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "pod_cpu_utilization", metrics.At(0).Name())
+	require.Equal(t, 1, metrics.At(0).DoubleGauge().DataPoints().Len())
+	assert.Equal(t, float64(8), metrics.At(0).DoubleGauge().DataPoints().At(0).Value())
+	assert.Equal(t, "pod_number_of_container_restarts", metrics.At(1).Name())
+	require.Equal(t, 1, metrics.At(1).IntGauge().DataPoints().Len())
+	assert.Equal(t, int64(8), metrics.At(1).IntGauge().DataPoints().At(0).Value())
+}
+
+func TestLimitSeriesUnderLimit(t *testing.T) {
+	md := pdata.NewMetrics()
+	newPodResourceMetrics(md, "a", 1, 1)
+	newPodResourceMetrics(md, "b", 2, 2)
+
+	mds, aggregated := limitSeries([]pdata.Metrics{md}, 2)
+	assert.Equal(t, 0, aggregated)
+	require.Len(t, mds, 1)
+	assert.Equal(t, 2, mds[0].ResourceMetrics().Len())
+}
+
+func TestConsumeMetricsWithMaxPodSeries(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxPodSeries = 1
+	sink := new(consumertest.MetricsSink)
+	metricsReceiver, err := New(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+
+	r := metricsReceiver.(*awsContainerInsightReceiver)
+	require.NoError(t, r.collectFrom(context.Background(), providerCadvisor, &mockPodMetricsProvider{}))
+
+	mds := sink.AllMetrics()
+	require.Len(t, mds, 2)
+	assert.Equal(t, 1, mds[0].ResourceMetrics().Len())
+	require.Equal(t, 1, mds[1].ResourceMetrics().Len())
+	pod, _ := mds[1].ResourceMetrics().At(0).Resource().Attributes().Get(attributePodName)
+	assert.Equal(t, overflowValue, pod.StringVal())
+}
+
+func attributesToMap(attrs pdata.AttributeMap) map[string]interface{} {
+	m := map[string]interface{}{}
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		m[k] = v.StringVal()
+		return true
+	})
+	return m
+}
@@ -27,6 +27,46 @@ type Config struct {
 	// CollectionInterval is the interval at which metrics should be collected. The default is 60 second.
 	CollectionInterval time.Duration `mapstructure:"collection_interval"`
 
+	// ClusterCollectionInterval is the interval at which cluster-level metrics should be collected from the
+	// k8s api server. The default is the CollectionInterval, which then applies to node and pod-level metrics only.
+	ClusterCollectionInterval time.Duration `mapstructure:"cluster_collection_interval"`
+
+	// CollectionJitter is the upper bound of a random delay before the first collection, which spreads the requests
+	// of the receivers on all the nodes of a cluster over time. The default is 0, i.e. no delay.
+	CollectionJitter time.Duration `mapstructure:"collection_jitter"`
+
+	// CollectionTimeout is the deadline of a collection cycle, after which the metrics that were not emitted yet are
+	// dropped so that the cycle does not overlap with the next one. The export of the metrics already emitted is not
+	// cancelled. The default is the collection interval.
+	CollectionTimeout time.Duration `mapstructure:"collection_timeout"`
+
 	// ContainerOrchestrator is the type of container orchestration service, e.g. eks or ecs. The default is eks.
 	ContainerOrchestrator string `mapstructure:"container_orchestrator"`
+
+	// Extractors enables or disables the metrics of individual extractors. All are enabled by default.
+	Extractors ExtractorsConfig `mapstructure:"extractors"`
+
+	// EnableNetStackMetrics enables the node-level network stack metrics, such as conntrack table usage, TCP
+	// retransmits and socket states. The receiver must run in the host network namespace. The default is false.
+	EnableNetStackMetrics bool `mapstructure:"enable_netstack_metrics"`
+
+	// HostProcPath is the path the procfs of the host is read from for the network stack metrics. The default is /proc.
+	HostProcPath string `mapstructure:"host_proc_path"`
+
+	// MetricNameConvention is the naming convention of the emitted metrics, either containerinsights for the
+	// Container Insights names, e.g. pod_network_rx_bytes, or otel for the OpenTelemetry semantic conventions,
+	// e.g. k8s.pod.network.io. The default is containerinsights.
+	MetricNameConvention string `mapstructure:"metric_name_convention"`
+
+	// AddPrometheusAttributes adds the namespace, pod, container and node resource attributes, named as the labels of
+	// kube-state-metrics, to the metrics in addition to the Container Insights attributes. The default is false.
+	AddPrometheusAttributes bool `mapstructure:"add_prometheus_attributes"`
+
+	// MaxPodSeries is the maximum number of pods and containers whose metrics each provider emits per collection.
+	// The metrics of the others are summed into resources with the namespace, pod and container name "other".
+	// The default is 0, i.e. no limit.
+	MaxPodSeries int `mapstructure:"max_pod_series"`
+
+	// Filter selects the pods whose metrics are emitted. By default, the metrics of all pods are emitted.
+	Filter FilterConfig `mapstructure:"filter"`
 }
@@ -39,7 +39,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 2)
+	assert.Equal(t, len(cfg.Receivers), 10)
 
 	//ensure default configurations are generated when users provide nothing
 	r0 := cfg.Receivers[config.NewID(typeStr)]
@@ -54,5 +54,98 @@ func TestLoadConfig(t *testing.T) {
 			ReceiverSettings:      config.NewReceiverSettings(config.NewIDWithName(typeStr, "collection_interval_settings")),
 			CollectionInterval:    60 * time.Second,
 			ContainerOrchestrator: "eks",
+			MetricNameConvention:  "containerinsights",
+			Extractors:            ExtractorsConfig{CPU: true, Mem: true, DiskIO: true, FS: true, Net: true},
+		})
+
+	r3 := cfg.Receivers[config.NewIDWithName(typeStr, "cluster_collection_interval_settings")].(*Config)
+	assert.Equal(t, r3,
+		&Config{
+			ReceiverSettings:          config.NewReceiverSettings(config.NewIDWithName(typeStr, "cluster_collection_interval_settings")),
+			CollectionInterval:        15 * time.Second,
+			ClusterCollectionInterval: 60 * time.Second,
+			ContainerOrchestrator:     "eks",
+			MetricNameConvention:      "containerinsights",
+			Extractors:                ExtractorsConfig{CPU: true, Mem: true, DiskIO: true, FS: true, Net: true},
+		})
+
+	r4 := cfg.Receivers[config.NewIDWithName(typeStr, "filter_settings")].(*Config)
+	assert.Equal(t, r4,
+		&Config{
+			ReceiverSettings:      config.NewReceiverSettings(config.NewIDWithName(typeStr, "filter_settings")),
+			CollectionInterval:    60 * time.Second,
+			ContainerOrchestrator: "eks",
+			MetricNameConvention:  "containerinsights",
+			Extractors:            ExtractorsConfig{CPU: true, Mem: true, DiskIO: true, FS: true, Net: true},
+			Filter: FilterConfig{
+				ExcludeNamespaces: []string{"^kube-system$"},
+				IncludePods:       []string{"^app-"},
+			},
+		})
+
+	r5 := cfg.Receivers[config.NewIDWithName(typeStr, "otel_metric_names")].(*Config)
+	assert.Equal(t, r5,
+		&Config{
+			ReceiverSettings:      config.NewReceiverSettings(config.NewIDWithName(typeStr, "otel_metric_names")),
+			CollectionInterval:    60 * time.Second,
+			ContainerOrchestrator: "eks",
+			MetricNameConvention:  "otel",
+			Extractors:            ExtractorsConfig{CPU: true, Mem: true, DiskIO: true, FS: true, Net: true},
+		})
+
+	r6 := cfg.Receivers[config.NewIDWithName(typeStr, "netstack_settings")].(*Config)
+	assert.Equal(t, r6,
+		&Config{
+			ReceiverSettings:      config.NewReceiverSettings(config.NewIDWithName(typeStr, "netstack_settings")),
+			CollectionInterval:    60 * time.Second,
+			ContainerOrchestrator: "eks",
+			MetricNameConvention:  "containerinsights",
+			Extractors:            ExtractorsConfig{CPU: true, Mem: true, DiskIO: true, FS: true, Net: true},
+			EnableNetStackMetrics: true,
+			HostProcPath:          "/rootfs/proc",
+		})
+
+	r7 := cfg.Receivers[config.NewIDWithName(typeStr, "extractors_settings")].(*Config)
+	assert.Equal(t, r7,
+		&Config{
+			ReceiverSettings:      config.NewReceiverSettings(config.NewIDWithName(typeStr, "extractors_settings")),
+			CollectionInterval:    60 * time.Second,
+			ContainerOrchestrator: "eks",
+			MetricNameConvention:  "containerinsights",
+			Extractors:            ExtractorsConfig{CPU: true, Mem: true, DiskIO: false, FS: true, Net: false},
+		})
+
+	r8 := cfg.Receivers[config.NewIDWithName(typeStr, "collection_timing_settings")].(*Config)
+	assert.Equal(t, r8,
+		&Config{
+			ReceiverSettings:      config.NewReceiverSettings(config.NewIDWithName(typeStr, "collection_timing_settings")),
+			CollectionInterval:    60 * time.Second,
+			CollectionJitter:      10 * time.Second,
+			CollectionTimeout:     30 * time.Second,
+			ContainerOrchestrator: "eks",
+			MetricNameConvention:  "containerinsights",
+			Extractors:            ExtractorsConfig{CPU: true, Mem: true, DiskIO: true, FS: true, Net: true},
+		})
+
+	r9 := cfg.Receivers[config.NewIDWithName(typeStr, "prometheus_attributes")].(*Config)
+	assert.Equal(t, r9,
+		&Config{
+			ReceiverSettings:        config.NewReceiverSettings(config.NewIDWithName(typeStr, "prometheus_attributes")),
+			CollectionInterval:      60 * time.Second,
+			ContainerOrchestrator:   "eks",
+			MetricNameConvention:    "containerinsights",
+			Extractors:              ExtractorsConfig{CPU: true, Mem: true, DiskIO: true, FS: true, Net: true},
+			AddPrometheusAttributes: true,
+		})
+
+	r10 := cfg.Receivers[config.NewIDWithName(typeStr, "max_pod_series_settings")].(*Config)
+	assert.Equal(t, r10,
+		&Config{
+			ReceiverSettings:      config.NewReceiverSettings(config.NewIDWithName(typeStr, "max_pod_series_settings")),
+			CollectionInterval:    60 * time.Second,
+			ContainerOrchestrator: "eks",
+			MetricNameConvention:  "containerinsights",
+			Extractors:            ExtractorsConfig{CPU: true, Mem: true, DiskIO: true, FS: true, Net: true},
+			MaxPodSeries:          500,
 		})
 }
@@ -0,0 +1,134 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// Metric name conventions, see Config.MetricNameConvention.
+const (
+	metricNameConventionContainerInsights = "containerinsights"
+	metricNameConventionOTel              = "otel"
+)
+
+const (
+	labelDirection     = "direction"
+	directionReceive   = "receive"
+	directionTransmit  = "transmit"
+	unitBytes          = "By"
+	unitBytesPerSecond = "By/s"
+	unitErrorsPerSec   = "{errors}/s"
+	unitPacketsPerSec  = "{packets}/s"
+)
+
+// otelMetric is the OpenTelemetry equivalent of a Container Insights metric. The Container
+// Insights metrics per network direction map to a single metric with a direction label.
+type otelMetric struct {
+	name      string
+	unit      string
+	direction string
+}
+
+// otelMetrics maps the Container Insights metric names to the OpenTelemetry semantic
+// convention names, as used by the kubeletstats receiver. Metrics without an equivalent,
+// such as those in millicores or percent, keep their names.
+var otelMetrics = map[string]otelMetric{}
+
+func init() {
+	for _, prefix := range []struct{ ci, otel string }{
+		{"node", "k8s.node"},
+		{"pod", "k8s.pod"},
+		{"container", "container"},
+	} {
+		otelMetrics[prefix.ci+"_memory_usage"] = otelMetric{name: prefix.otel + ".memory.usage", unit: unitBytes}
+		otelMetrics[prefix.ci+"_memory_working_set"] = otelMetric{name: prefix.otel + ".memory.working_set", unit: unitBytes}
+		otelMetrics[prefix.ci+"_memory_rss"] = otelMetric{name: prefix.otel + ".memory.rss", unit: unitBytes}
+		otelMetrics[prefix.ci+"_filesystem_available"] = otelMetric{name: prefix.otel + ".filesystem.available", unit: unitBytes}
+		otelMetrics[prefix.ci+"_filesystem_capacity"] = otelMetric{name: prefix.otel + ".filesystem.capacity", unit: unitBytes}
+		otelMetrics[prefix.ci+"_filesystem_usage"] = otelMetric{name: prefix.otel + ".filesystem.usage", unit: unitBytes}
+	}
+	for _, prefix := range []struct{ ci, otel string }{
+		{"node", "k8s.node"},
+		{"pod", "k8s.pod"},
+	} {
+		otelMetrics[prefix.ci+"_network_rx_bytes"] = otelMetric{name: prefix.otel + ".network.io", unit: unitBytesPerSecond, direction: directionReceive}
+		otelMetrics[prefix.ci+"_network_tx_bytes"] = otelMetric{name: prefix.otel + ".network.io", unit: unitBytesPerSecond, direction: directionTransmit}
+		otelMetrics[prefix.ci+"_network_rx_errors"] = otelMetric{name: prefix.otel + ".network.errors", unit: unitErrorsPerSec, direction: directionReceive}
+		otelMetrics[prefix.ci+"_network_tx_errors"] = otelMetric{name: prefix.otel + ".network.errors", unit: unitErrorsPerSec, direction: directionTransmit}
+		otelMetrics[prefix.ci+"_network_rx_packets"] = otelMetric{name: prefix.otel + ".network.packets", unit: unitPacketsPerSec, direction: directionReceive}
+		otelMetrics[prefix.ci+"_network_tx_packets"] = otelMetric{name: prefix.otel + ".network.packets", unit: unitPacketsPerSec, direction: directionTransmit}
+	}
+}
+
+func validateMetricNameConvention(convention string) error {
+	switch convention {
+	case metricNameConventionContainerInsights, metricNameConventionOTel:
+		return nil
+	default:
+		return fmt.Errorf("invalid metric_name_convention %q, must be %q or %q", convention, metricNameConventionContainerInsights, metricNameConventionOTel)
+	}
+}
+
+// toOTelMetricNames renames the metrics in md that have an OpenTelemetry equivalent.
+func toOTelMetricNames(md pdata.Metrics) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				toOTelMetric(metrics.At(k))
+			}
+		}
+	}
+}
+
+func toOTelMetric(metric pdata.Metric) {
+	m, ok := otelMetrics[metric.Name()]
+	if !ok {
+		return
+	}
+	metric.SetName(m.name)
+	metric.SetUnit(m.unit)
+	if m.direction == "" {
+		return
+	}
+
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		insertIntLabel(metric.IntGauge().DataPoints(), labelDirection, m.direction)
+	case pdata.MetricDataTypeDoubleGauge:
+		insertDoubleLabel(metric.DoubleGauge().DataPoints(), labelDirection, m.direction)
+	case pdata.MetricDataTypeIntSum:
+		insertIntLabel(metric.IntSum().DataPoints(), labelDirection, m.direction)
+	case pdata.MetricDataTypeDoubleSum:
+		insertDoubleLabel(metric.DoubleSum().DataPoints(), labelDirection, m.direction)
+	}
+}
+
+func insertIntLabel(dps pdata.IntDataPointSlice, key, value string) {
+	for i := 0; i < dps.Len(); i++ {
+		dps.At(i).LabelsMap().Insert(key, value)
+	}
+}
+
+func insertDoubleLabel(dps pdata.DoubleDataPointSlice, key, value string) {
+	for i := 0; i < dps.Len(); i++ {
+		dps.At(i).LabelsMap().Insert(key, value)
+	}
+}
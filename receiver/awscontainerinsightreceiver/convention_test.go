@@ -0,0 +1,66 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestToOTelMetricNames(t *testing.T) {
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	rx := metrics.AppendEmpty()
+	rx.SetName("pod_network_rx_bytes")
+	rx.SetUnit("Bytes/Second")
+	rx.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	rx.DoubleGauge().DataPoints().AppendEmpty().SetValue(1024)
+
+	mem := metrics.AppendEmpty()
+	mem.SetName("container_memory_working_set")
+	mem.SetUnit("Bytes")
+	mem.SetDataType(pdata.MetricDataTypeIntGauge)
+	mem.IntGauge().DataPoints().AppendEmpty().SetValue(2048)
+
+	cpu := metrics.AppendEmpty()
+	cpu.SetName("node_cpu_utilization")
+	cpu.SetUnit("Percent")
+	cpu.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	cpu.DoubleGauge().DataPoints().AppendEmpty().SetValue(12.5)
+
+	toOTelMetricNames(md)
+
+	assert.Equal(t, "k8s.pod.network.io", rx.Name())
+	assert.Equal(t, "By/s", rx.Unit())
+	direction, ok := rx.DoubleGauge().DataPoints().At(0).LabelsMap().Get("direction")
+	assert.True(t, ok)
+	assert.Equal(t, "receive", direction)
+
+	assert.Equal(t, "container.memory.working_set", mem.Name())
+	assert.Equal(t, "By", mem.Unit())
+	assert.Equal(t, 0, mem.IntGauge().DataPoints().At(0).LabelsMap().Len())
+
+	assert.Equal(t, "node_cpu_utilization", cpu.Name())
+	assert.Equal(t, "Percent", cpu.Unit())
+}
+
+func TestValidateMetricNameConvention(t *testing.T) {
+	assert.NoError(t, validateMetricNameConvention("containerinsights"))
+	assert.NoError(t, validateMetricNameConvention("otel"))
+	assert.EqualError(t, validateMetricNameConvention(""), `invalid metric_name_convention "", must be "containerinsights" or "otel"`)
+}
@@ -0,0 +1,81 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// ExtractorsConfig enables or disables the metrics of the Container Insights extractors. The metrics of
+// a disabled extractor are dropped from the nodes, pods and containers.
+type ExtractorsConfig struct {
+	// CPU enables the cpu metrics, e.g. pod_cpu_utilization. The default is true.
+	CPU bool `mapstructure:"cpu"`
+
+	// Mem enables the memory metrics, e.g. pod_memory_working_set. The default is true.
+	Mem bool `mapstructure:"mem"`
+
+	// DiskIO enables the disk io metrics, e.g. node_diskio_io_service_bytes_total. The default is true.
+	DiskIO bool `mapstructure:"diskio"`
+
+	// FS enables the filesystem metrics, e.g. node_filesystem_utilization. The default is true.
+	FS bool `mapstructure:"fs"`
+
+	// Net enables the network metrics, e.g. pod_network_rx_bytes. The default is true.
+	Net bool `mapstructure:"net"`
+}
+
+// allEnabled returns whether no extractor is disabled.
+func (c ExtractorsConfig) allEnabled() bool {
+	return c.CPU && c.Mem && c.DiskIO && c.FS && c.Net
+}
+
+// enabled returns whether the extractor generating the metric with the given Container Insights name is enabled.
+// Metrics that are not generated by an extractor, such as the cluster-level metrics, are always enabled.
+func (c ExtractorsConfig) enabled(metricName string) bool {
+	switch {
+	case strings.Contains(metricName, "_cpu_"):
+		return c.CPU
+	case strings.Contains(metricName, "_memory_"):
+		return c.Mem
+	case strings.Contains(metricName, "_diskio_"):
+		return c.DiskIO
+	case strings.Contains(metricName, "_filesystem_"):
+		return c.FS
+	case strings.Contains(metricName, "_network_"):
+		return c.Net
+	default:
+		return true
+	}
+}
+
+// dropDisabledMetrics removes the metrics of disabled extractors from md.
+func (c ExtractorsConfig) dropDisabledMetrics(md pdata.Metrics) {
+	if c.allEnabled() {
+		return
+	}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilms.At(j).Metrics().RemoveIf(func(m pdata.Metric) bool {
+				return !c.enabled(m.Name())
+			})
+		}
+	}
+}
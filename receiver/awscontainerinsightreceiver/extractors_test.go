@@ -0,0 +1,57 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestDropDisabledMetrics(t *testing.T) {
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	for _, name := range []string{
+		"node_cpu_utilization",
+		"pod_memory_working_set",
+		"node_diskio_io_service_bytes_total",
+		"node_filesystem_utilization",
+		"pod_interface_network_rx_bytes",
+		"cluster_node_count",
+		"node_netstack_conntrack_entries",
+	} {
+		metrics.AppendEmpty().SetName(name)
+	}
+
+	cfg := ExtractorsConfig{CPU: true, Mem: true, DiskIO: false, FS: false, Net: false}
+	cfg.dropDisabledMetrics(md)
+
+	var names []string
+	for i := 0; i < metrics.Len(); i++ {
+		names = append(names, metrics.At(i).Name())
+	}
+	assert.Equal(t, []string{"node_cpu_utilization", "pod_memory_working_set", "cluster_node_count", "node_netstack_conntrack_entries"}, names)
+}
+
+func TestExtractorsAllEnabled(t *testing.T) {
+	cfg := createDefaultConfig().(*Config).Extractors
+	assert.True(t, cfg.allEnabled())
+
+	cfg.Mem = false
+	assert.False(t, cfg.allEnabled())
+	assert.False(t, cfg.enabled("container_memory_usage"))
+	assert.True(t, cfg.enabled("container_cpu_usage_total"))
+}
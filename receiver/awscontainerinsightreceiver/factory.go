@@ -16,8 +16,10 @@ package awscontainerinsightreceiver
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
@@ -36,8 +38,16 @@ const (
 	defaultContainerOrchestrator = "eks"
 )
 
+var registerViewsOnce sync.Once
+
 // NewFactory creates a factory for AWS container insight receiver
 func NewFactory() component.ReceiverFactory {
+	// the receiver's own metrics are only exported if their views are registered;
+	// an error here means they won't be exported, which shouldn't stop the receiver from being built
+	registerViewsOnce.Do(func() {
+		_ = view.Register(MetricViews()...)
+	})
+
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
@@ -50,6 +60,14 @@ func createDefaultConfig() config.Receiver {
 		ReceiverSettings:      config.NewReceiverSettings(config.NewID(typeStr)),
 		CollectionInterval:    defaultCollectionInterval,
 		ContainerOrchestrator: defaultContainerOrchestrator,
+		MetricNameConvention:  metricNameConventionContainerInsights,
+		Extractors: ExtractorsConfig{
+			CPU:    true,
+			Mem:    true,
+			DiskIO: true,
+			FS:     true,
+			Net:    true,
+		},
 	}
 }
 
@@ -0,0 +1,125 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// Resource attributes the filters are matched against, see the README.
+const (
+	attributeNamespace = "Namespace"
+	attributePodName   = "K8sPodName"
+)
+
+// FilterConfig selects the pods whose metrics are emitted. Metrics without pod attributes,
+// such as node and cluster-level metrics, are always emitted.
+type FilterConfig struct {
+	// IncludeNamespaces is a list of regular expressions; if not empty, only the metrics of pods
+	// in a matching namespace are emitted.
+	IncludeNamespaces []string `mapstructure:"include_namespaces"`
+
+	// ExcludeNamespaces is a list of regular expressions; the metrics of pods in a matching
+	// namespace are dropped, e.g. "^kube-system$".
+	ExcludeNamespaces []string `mapstructure:"exclude_namespaces"`
+
+	// IncludePods is a list of regular expressions; if not empty, only the metrics of pods
+	// with a matching name are emitted.
+	IncludePods []string `mapstructure:"include_pods"`
+
+	// ExcludePods is a list of regular expressions; the metrics of pods with a matching name are dropped.
+	ExcludePods []string `mapstructure:"exclude_pods"`
+}
+
+// podFilter drops the metrics of pods that are not selected by a FilterConfig.
+type podFilter struct {
+	includeNamespaces []*regexp.Regexp
+	excludeNamespaces []*regexp.Regexp
+	includePods       []*regexp.Regexp
+	excludePods       []*regexp.Regexp
+}
+
+func newPodFilter(cfg FilterConfig) (*podFilter, error) {
+	var f podFilter
+	var err error
+	if f.includeNamespaces, err = compileRegexes(cfg.IncludeNamespaces); err != nil {
+		return nil, err
+	}
+	if f.excludeNamespaces, err = compileRegexes(cfg.ExcludeNamespaces); err != nil {
+		return nil, err
+	}
+	if f.includePods, err = compileRegexes(cfg.IncludePods); err != nil {
+		return nil, err
+	}
+	if f.excludePods, err = compileRegexes(cfg.ExcludePods); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func compileRegexes(exprs []string) ([]*regexp.Regexp, error) {
+	regexes := make([]*regexp.Regexp, 0, len(exprs))
+	for _, expr := range exprs {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %w", expr, err)
+		}
+		regexes = append(regexes, re)
+	}
+	return regexes, nil
+}
+
+// filter removes the resource metrics of the pods that are not selected from md,
+// and returns the number of removed resource metrics.
+func (f *podFilter) filter(md pdata.Metrics) int {
+	removed := 0
+	md.ResourceMetrics().RemoveIf(func(rm pdata.ResourceMetrics) bool {
+		if f.selected(rm.Resource().Attributes()) {
+			return false
+		}
+		removed++
+		return true
+	})
+	return removed
+}
+
+func (f *podFilter) selected(attrs pdata.AttributeMap) bool {
+	if namespace, ok := attrs.Get(attributeNamespace); ok && !matches(namespace.StringVal(), f.includeNamespaces, f.excludeNamespaces) {
+		return false
+	}
+	if pod, ok := attrs.Get(attributePodName); ok && !matches(pod.StringVal(), f.includePods, f.excludePods) {
+		return false
+	}
+	return true
+}
+
+func matches(value string, include, exclude []*regexp.Regexp) bool {
+	if len(include) != 0 && !matchesAny(value, include) {
+		return false
+	}
+	return !matchesAny(value, exclude)
+}
+
+func matchesAny(value string, regexes []*regexp.Regexp) bool {
+	for _, re := range regexes {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func newResourceMetrics(md pdata.Metrics, attrs map[string]string) {
+	rm := md.ResourceMetrics().AppendEmpty()
+	for k, v := range attrs {
+		rm.Resource().Attributes().InsertString(k, v)
+	}
+}
+
+func TestPodFilter(t *testing.T) {
+	f, err := newPodFilter(FilterConfig{
+		ExcludeNamespaces: []string{"^kube-"},
+		IncludePods:       []string{"^app-", "^web-"},
+		ExcludePods:       []string{"-canary$"},
+	})
+	require.NoError(t, err)
+
+	md := pdata.NewMetrics()
+	newResourceMetrics(md, map[string]string{"Type": "Node", "NodeName": "node-1"})
+	newResourceMetrics(md, map[string]string{"Type": "Pod", attributeNamespace: "default", attributePodName: "app-1"})
+	newResourceMetrics(md, map[string]string{"Type": "Pod", attributeNamespace: "kube-system", attributePodName: "app-2"})
+	newResourceMetrics(md, map[string]string{"Type": "Pod", attributeNamespace: "default", attributePodName: "db-1"})
+	newResourceMetrics(md, map[string]string{"Type": "Pod", attributeNamespace: "default", attributePodName: "web-canary"})
+	newResourceMetrics(md, map[string]string{"Type": "NodeFS", attributeNamespace: "default"})
+	assert.Equal(t, 3, f.filter(md))
+
+	var kept []string
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		attrs := md.ResourceMetrics().At(i).Resource().Attributes()
+		typ, _ := attrs.Get("Type")
+		pod, ok := attrs.Get(attributePodName)
+		if ok {
+			kept = append(kept, typ.StringVal()+"/"+pod.StringVal())
+		} else {
+			kept = append(kept, typ.StringVal())
+		}
+	}
+	assert.Equal(t, []string{"Node", "Pod/app-1", "NodeFS"}, kept)
+}
+
+func TestPodFilterDefault(t *testing.T) {
+	f, err := newPodFilter(FilterConfig{})
+	require.NoError(t, err)
+
+	md := pdata.NewMetrics()
+	newResourceMetrics(md, map[string]string{attributeNamespace: "kube-system", attributePodName: "coredns"})
+	assert.Equal(t, 0, f.filter(md))
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+}
+
+func TestPodFilterInvalidRegex(t *testing.T) {
+	_, err := newPodFilter(FilterConfig{IncludeNamespaces: []string{"("}})
+	assert.EqualError(t, err, "invalid filter \"(\": error parsing regexp: missing closing ): `(`")
+}
@@ -0,0 +1,126 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netstack
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// Resource attributes of the network stack metrics, following the Container Insights conventions.
+const (
+	attributeClusterName  = "ClusterName"
+	attributeInstanceID   = "InstanceId"
+	attributeInstanceType = "InstanceType"
+	attributeNodeName     = "NodeName"
+	attributeType         = "Type"
+	attributeTimestamp    = "Timestamp"
+	attributeVersion      = "Version"
+
+	typeNodeNetStack = "NodeNetStack"
+	version          = "0"
+
+	// hostNameEnv is the environment variable the node name is exposed in through the downward API
+	hostNameEnv = "HOST_NAME"
+)
+
+const (
+	unitCount          = "Count"
+	unitPercent        = "Percent"
+	unitCountPerSecond = "Count/Second"
+)
+
+type hostInfoProvider interface {
+	GetClusterName() string
+	GetInstanceID() string
+	GetInstanceType() string
+}
+
+// NetStack generates node-level metrics about the network stack, such as conntrack table usage,
+// TCP retransmits and socket states, which indicate CNI exhaustion on busy nodes.
+type NetStack struct {
+	procPath string
+	hostInfo hostInfoProvider
+	logger   *zap.Logger
+
+	// the previous retransmitted segments total, to compute the rate
+	prevRetransSegs int64
+	prevTime        time.Time
+}
+
+// generateMetrics converts the statistics read at now to metrics.
+func (n *NetStack) generateMetrics(s stats, now time.Time) pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+
+	attrs := rm.Resource().Attributes()
+	attrs.InsertString(attributeClusterName, n.hostInfo.GetClusterName())
+	attrs.InsertString(attributeInstanceID, n.hostInfo.GetInstanceID())
+	attrs.InsertString(attributeInstanceType, n.hostInfo.GetInstanceType())
+	attrs.InsertString(attributeNodeName, os.Getenv(hostNameEnv))
+	attrs.InsertString(attributeType, typeNodeNetStack)
+	attrs.InsertString(attributeTimestamp, strconv.FormatInt(now.UnixNano()/int64(time.Millisecond), 10))
+	attrs.InsertString(attributeVersion, version)
+
+	metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	ts := pdata.TimestampFromTime(now)
+	if s.conntrackAvailable {
+		addIntGauge(metrics, "node_netstack_conntrack_entries", unitCount, s.conntrackEntries, ts)
+		addIntGauge(metrics, "node_netstack_conntrack_max", unitCount, s.conntrackMax, ts)
+		if s.conntrackMax > 0 {
+			addDoubleGauge(metrics, "node_netstack_conntrack_utilization", unitPercent, float64(s.conntrackEntries)/float64(s.conntrackMax)*100, ts)
+		}
+	}
+	addIntGauge(metrics, "node_netstack_tcp_established", unitCount, s.tcpEstablished, ts)
+	addIntGauge(metrics, "node_netstack_tcp_sockets_inuse", unitCount, s.tcpInUse, ts)
+	addIntGauge(metrics, "node_netstack_tcp_sockets_orphan", unitCount, s.tcpOrphan, ts)
+	addIntGauge(metrics, "node_netstack_tcp_sockets_time_wait", unitCount, s.tcpTimeWait, ts)
+	addIntGauge(metrics, "node_netstack_tcp_sockets_alloc", unitCount, s.tcpAlloc, ts)
+
+	// the rate needs a previous value, and the counter restarts from zero when the node reboots
+	if !n.prevTime.IsZero() && s.tcpRetransSegs >= n.prevRetransSegs {
+		if elapsed := now.Sub(n.prevTime).Seconds(); elapsed > 0 {
+			addDoubleGauge(metrics, "node_netstack_tcp_retransmitted_segments", unitCountPerSecond, float64(s.tcpRetransSegs-n.prevRetransSegs)/elapsed, ts)
+		}
+	}
+	n.prevRetransSegs = s.tcpRetransSegs
+	n.prevTime = now
+
+	return md
+}
+
+func addIntGauge(metrics pdata.MetricSlice, name string, unit string, value int64, ts pdata.Timestamp) {
+	m := metrics.AppendEmpty()
+	m.SetName(name)
+	m.SetUnit(unit)
+	m.SetDataType(pdata.MetricDataTypeIntGauge)
+	dp := m.IntGauge().DataPoints().AppendEmpty()
+	dp.SetValue(value)
+	dp.SetTimestamp(ts)
+}
+
+func addDoubleGauge(metrics pdata.MetricSlice, name string, unit string, value float64, ts pdata.Timestamp) {
+	m := metrics.AppendEmpty()
+	m.SetName(name)
+	m.SetUnit(unit)
+	m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	dp := m.DoubleGauge().DataPoints().AppendEmpty()
+	dp.SetValue(value)
+	dp.SetTimestamp(ts)
+}
@@ -0,0 +1,39 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package netstack
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// New creates a NetStack that reads the statistics from the procfs mounted at procPath. The
+// collector must run in the host network namespace, as the statistics are per namespace.
+func New(procPath string, hostInfo hostInfoProvider, logger *zap.Logger) *NetStack {
+	return &NetStack{procPath: procPath, hostInfo: hostInfo, logger: logger}
+}
+
+func (n *NetStack) GetMetrics() []pdata.Metrics {
+	s, err := readStats(n.procPath)
+	if err != nil {
+		n.logger.Warn("Failed to read network stack statistics", zap.Error(err))
+		return []pdata.Metrics{}
+	}
+	return []pdata.Metrics{n.generateMetrics(s, time.Now())}
+}
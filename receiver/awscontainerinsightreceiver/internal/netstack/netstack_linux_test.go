@@ -0,0 +1,34 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package netstack
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestGetMetrics(t *testing.T) {
+	n := New(filepath.Join("testdata", "proc"), &mockHostInfo{}, zap.NewNop())
+	mds := n.GetMetrics()
+	assert.Len(t, mds, 1)
+
+	n = New(filepath.Join("testdata", "missing"), &mockHostInfo{}, zap.NewNop())
+	assert.Empty(t, n.GetMetrics())
+}
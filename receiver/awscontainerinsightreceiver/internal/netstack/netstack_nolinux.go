@@ -0,0 +1,31 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !linux
+
+package netstack
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// New creates a NetStack, which only generates metrics on Linux.
+func New(procPath string, hostInfo hostInfoProvider, logger *zap.Logger) *NetStack {
+	return &NetStack{procPath: procPath, hostInfo: hostInfo, logger: logger}
+}
+
+func (n *NetStack) GetMetrics() []pdata.Metrics {
+	return []pdata.Metrics{}
+}
@@ -0,0 +1,101 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netstack
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type mockHostInfo struct{}
+
+func (m *mockHostInfo) GetClusterName() string  { return "cluster" }
+func (m *mockHostInfo) GetInstanceID() string   { return "i-abcd1234" }
+func (m *mockHostInfo) GetInstanceType() string { return "m5.large" }
+
+func metricValues(md pdata.Metrics) map[string]float64 {
+	values := map[string]float64{}
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		switch m.DataType() {
+		case pdata.MetricDataTypeIntGauge:
+			values[m.Name()] = float64(m.IntGauge().DataPoints().At(0).Value())
+		case pdata.MetricDataTypeDoubleGauge:
+			values[m.Name()] = m.DoubleGauge().DataPoints().At(0).Value()
+		}
+	}
+	return values
+}
+
+func TestGenerateMetrics(t *testing.T) {
+	n := &NetStack{hostInfo: &mockHostInfo{}, logger: zap.NewNop()}
+	s := stats{
+		conntrackAvailable: true,
+		conntrackEntries:   500,
+		conntrackMax:       1000,
+		tcpEstablished:     10,
+		tcpRetransSegs:     100,
+		tcpInUse:           12,
+		tcpOrphan:          1,
+		tcpTimeWait:        30,
+		tcpAlloc:           15,
+	}
+	now := time.Unix(1600000000, 0)
+
+	md := n.generateMetrics(s, now)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	attrs := md.ResourceMetrics().At(0).Resource().Attributes()
+	typ, _ := attrs.Get("Type")
+	assert.Equal(t, "NodeNetStack", typ.StringVal())
+	instanceID, _ := attrs.Get("InstanceId")
+	assert.Equal(t, "i-abcd1234", instanceID.StringVal())
+	timestamp, _ := attrs.Get("Timestamp")
+	assert.Equal(t, "1600000000000", timestamp.StringVal())
+
+	// no retransmit rate without a previous collection
+	assert.Equal(t, map[string]float64{
+		"node_netstack_conntrack_entries":     500,
+		"node_netstack_conntrack_max":         1000,
+		"node_netstack_conntrack_utilization": 50,
+		"node_netstack_tcp_established":       10,
+		"node_netstack_tcp_sockets_inuse":     12,
+		"node_netstack_tcp_sockets_orphan":    1,
+		"node_netstack_tcp_sockets_time_wait": 30,
+		"node_netstack_tcp_sockets_alloc":     15,
+	}, metricValues(md))
+
+	s.tcpRetransSegs = 160
+	s.conntrackAvailable = false
+	md = n.generateMetrics(s, now.Add(time.Minute))
+	assert.Equal(t, map[string]float64{
+		"node_netstack_tcp_established":            10,
+		"node_netstack_tcp_sockets_inuse":          12,
+		"node_netstack_tcp_sockets_orphan":         1,
+		"node_netstack_tcp_sockets_time_wait":      30,
+		"node_netstack_tcp_sockets_alloc":          15,
+		"node_netstack_tcp_retransmitted_segments": 1,
+	}, metricValues(md))
+
+	// the counter was reset by a reboot
+	s.tcpRetransSegs = 5
+	md = n.generateMetrics(s, now.Add(2*time.Minute))
+	assert.NotContains(t, metricValues(md), "node_netstack_tcp_retransmitted_segments")
+}
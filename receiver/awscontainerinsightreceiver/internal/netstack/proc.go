@@ -0,0 +1,162 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netstack
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// stats are the network stack statistics of the node, read from procfs.
+type stats struct {
+	// conntrackAvailable is false if the nf_conntrack module is not loaded
+	conntrackAvailable bool
+	conntrackEntries   int64
+	conntrackMax       int64
+
+	tcpEstablished int64
+	// tcpRetransSegs is the total number of retransmitted TCP segments since boot
+	tcpRetransSegs int64
+
+	tcpInUse    int64
+	tcpOrphan   int64
+	tcpTimeWait int64
+	tcpAlloc    int64
+}
+
+// readStats reads the network stack statistics from the procfs mounted at procPath.
+func readStats(procPath string) (stats, error) {
+	var s stats
+	var err error
+
+	s.conntrackEntries, err = readInt(filepath.Join(procPath, "sys", "net", "netfilter", "nf_conntrack_count"))
+	switch {
+	case os.IsNotExist(err):
+		// the nf_conntrack module is not loaded
+	case err != nil:
+		return s, err
+	default:
+		if s.conntrackMax, err = readInt(filepath.Join(procPath, "sys", "net", "netfilter", "nf_conntrack_max")); err != nil {
+			return s, err
+		}
+		s.conntrackAvailable = true
+	}
+
+	snmp, err := readKeyedLines(filepath.Join(procPath, "net", "snmp"), "Tcp")
+	if err != nil {
+		return s, err
+	}
+	if s.tcpEstablished, err = lookupInt(snmp, "CurrEstab"); err != nil {
+		return s, fmt.Errorf("failed reading snmp: %w", err)
+	}
+	if s.tcpRetransSegs, err = lookupInt(snmp, "RetransSegs"); err != nil {
+		return s, fmt.Errorf("failed reading snmp: %w", err)
+	}
+
+	sockstat, err := readSockstat(filepath.Join(procPath, "net", "sockstat"), "TCP")
+	if err != nil {
+		return s, err
+	}
+	for key, value := range map[string]*int64{"inuse": &s.tcpInUse, "orphan": &s.tcpOrphan, "tw": &s.tcpTimeWait, "alloc": &s.tcpAlloc} {
+		if *value, err = lookupInt(sockstat, key); err != nil {
+			return s, fmt.Errorf("failed reading sockstat: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+func readInt(path string) (int64, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// readKeyedLines parses files like /proc/net/snmp, where each protocol has a line of field
+// names followed by a line of values, both prefixed with the protocol name.
+func readKeyedLines(path string, protocol string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := protocol + ":"
+	var lines [][]string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 && fields[0] == prefix {
+			lines = append(lines, fields[1:])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) != 2 || len(lines[0]) != len(lines[1]) {
+		return nil, fmt.Errorf("unexpected %s lines in %s", protocol, path)
+	}
+
+	values := make(map[string]string, len(lines[0]))
+	for i, key := range lines[0] {
+		values[key] = lines[1][i]
+	}
+	return values, nil
+}
+
+// readSockstat parses the line of a protocol in files like /proc/net/sockstat, which
+// consists of alternating keys and values, e.g. "TCP: inuse 85 orphan 2".
+func readSockstat(path string, protocol string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := protocol + ":"
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != prefix {
+			continue
+		}
+		if len(fields)%2 != 1 {
+			return nil, fmt.Errorf("unexpected %s line in %s", protocol, path)
+		}
+		values := make(map[string]string, len(fields)/2)
+		for i := 1; i < len(fields); i += 2 {
+			values[fields[i]] = fields[i+1]
+		}
+		return values, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("no %s line in %s", protocol, path)
+}
+
+func lookupInt(values map[string]string, key string) (int64, error) {
+	value, ok := values[key]
+	if !ok {
+		return 0, fmt.Errorf("missing %s", key)
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
@@ -0,0 +1,78 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netstack
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadStats(t *testing.T) {
+	s, err := readStats(filepath.Join("testdata", "proc"))
+	require.NoError(t, err)
+	assert.Equal(t, stats{
+		conntrackAvailable: true,
+		conntrackEntries:   12000,
+		conntrackMax:       262144,
+		tcpEstablished:     85,
+		tcpRetransSegs:     1500,
+		tcpInUse:           85,
+		tcpOrphan:          2,
+		tcpTimeWait:        140,
+		tcpAlloc:           95,
+	}, s)
+}
+
+func TestReadStatsWithoutConntrack(t *testing.T) {
+	dir, err := ioutil.TempDir("", "netstack")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "net"), 0700))
+	for _, name := range []string{"snmp", "sockstat"} {
+		data, err := ioutil.ReadFile(filepath.Join("testdata", "proc", "net", name))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "net", name), data, 0600))
+	}
+
+	s, err := readStats(dir)
+	require.NoError(t, err)
+	assert.False(t, s.conntrackAvailable)
+	assert.Equal(t, int64(1500), s.tcpRetransSegs)
+}
+
+func TestReadStatsErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "netstack")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = readStats(dir)
+	assert.Error(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "net"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "net", "snmp"), []byte("Tcp: CurrEstab RetransSegs\nTcp: 1\n"), 0600))
+	_, err = readStats(dir)
+	assert.EqualError(t, err, "unexpected Tcp lines in "+filepath.Join(dir, "net", "snmp"))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "net", "snmp"), []byte("Tcp: CurrEstab RetransSegs\nTcp: 1 2\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "net", "sockstat"), []byte("TCP: inuse 1 orphan 0 tw 2\n"), 0600))
+	_, err = readStats(dir)
+	assert.EqualError(t, err, "failed reading sockstat: missing alloc")
+}
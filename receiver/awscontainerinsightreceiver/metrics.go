@@ -0,0 +1,112 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var (
+	tagProviderKey = tag.MustNewKey("provider")
+
+	mCollectionDuration  = stats.Int64("receiver_awscontainerinsight_collection_duration", "How long providers take to generate the metrics of a collection", stats.UnitMilliseconds)
+	mCollectedResources  = stats.Int64("receiver_awscontainerinsight_collected_resources", "Number of nodes, pods, containers and other resources metrics were generated for", stats.UnitDimensionless)
+	mCollectedDataPoints = stats.Int64("receiver_awscontainerinsight_collected_data_points", "Number of data points generated by the providers", stats.UnitDimensionless)
+	mFilteredResources   = stats.Int64("receiver_awscontainerinsight_filtered_resources", "Number of resources whose metrics were dropped by the pod filter", stats.UnitDimensionless)
+	mOverflowResources   = stats.Int64("receiver_awscontainerinsight_overflow_resources", "Number of pod and container resources whose metrics were aggregated over the series limit", stats.UnitDimensionless)
+)
+
+// MetricViews returns the views of the metrics the receiver records about its own operation.
+// The points the next consumer accepts or refuses are reported through obsreport.
+func MetricViews() []*view.View {
+	providerTags := []tag.Key{tagProviderKey}
+	return []*view.View{
+		{
+			Name:        mCollectionDuration.Name(),
+			Measure:     mCollectionDuration,
+			Description: mCollectionDuration.Description(),
+			TagKeys:     providerTags,
+			Aggregation: view.Distribution(0, 10, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000),
+		},
+		{
+			Name:        mCollectedResources.Name(),
+			Measure:     mCollectedResources,
+			Description: mCollectedResources.Description(),
+			TagKeys:     providerTags,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mCollectedDataPoints.Name(),
+			Measure:     mCollectedDataPoints,
+			Description: mCollectedDataPoints.Description(),
+			TagKeys:     providerTags,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mFilteredResources.Name(),
+			Measure:     mFilteredResources,
+			Description: mFilteredResources.Description(),
+			TagKeys:     providerTags,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mOverflowResources.Name(),
+			Measure:     mOverflowResources,
+			Description: mOverflowResources.Description(),
+			TagKeys:     providerTags,
+			Aggregation: view.Sum(),
+		},
+	}
+}
+
+// recordCollection records the outcome of a single collection from a provider.
+func recordCollection(ctx context.Context, provider string, duration time.Duration, mds []pdata.Metrics) {
+	resources, dataPoints := 0, 0
+	for _, md := range mds {
+		_, n := md.MetricAndDataPointCount()
+		resources += md.ResourceMetrics().Len()
+		dataPoints += n
+	}
+
+	ctx, _ = tag.New(ctx, tag.Upsert(tagProviderKey, provider))
+	stats.Record(ctx,
+		mCollectionDuration.M(duration.Milliseconds()),
+		mCollectedResources.M(int64(resources)),
+		mCollectedDataPoints.M(int64(dataPoints)))
+}
+
+// recordFilteredResources records the number of resources dropped by the pod filter.
+func recordFilteredResources(ctx context.Context, provider string, count int) {
+	if count == 0 {
+		return
+	}
+	ctx, _ = tag.New(ctx, tag.Upsert(tagProviderKey, provider))
+	stats.Record(ctx, mFilteredResources.M(int64(count)))
+}
+
+// recordOverflowResources records the number of resources aggregated over the series limit.
+func recordOverflowResources(ctx context.Context, provider string, count int) {
+	if count == 0 {
+		return
+	}
+	ctx, _ = tag.New(ctx, tag.Upsert(tagProviderKey, provider))
+	stats.Record(ctx, mOverflowResources.M(int64(count)))
+}
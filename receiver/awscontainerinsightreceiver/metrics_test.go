@@ -0,0 +1,76 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type mockPodMetricsProvider struct {
+}
+
+func (p *mockPodMetricsProvider) GetMetrics() []pdata.Metrics {
+	md := pdata.NewMetrics()
+	newResourceMetrics(md, map[string]string{attributeNamespace: "default", attributePodName: "app"})
+	newResourceMetrics(md, map[string]string{attributeNamespace: "kube-system", attributePodName: "coredns"})
+	return []pdata.Metrics{md}
+}
+
+func TestReceiverMetrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Filter.ExcludeNamespaces = []string{"^kube-system$"}
+	metricsReceiver, err := New(zap.NewNop(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	r := metricsReceiver.(*awsContainerInsightReceiver)
+	require.NoError(t, r.collectFrom(context.Background(), providerCadvisor, &mockPodMetricsProvider{}))
+
+	rows, err := view.RetrieveData(mCollectedResources.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, providerCadvisor, tagValue(rows[0].Tags, tagProviderKey))
+	assert.Equal(t, float64(2), rows[0].Data.(*view.SumData).Value)
+
+	rows, err = view.RetrieveData(mFilteredResources.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, float64(1), rows[0].Data.(*view.SumData).Value)
+
+	rows, err = view.RetrieveData(mCollectionDuration.Name())
+	require.NoError(t, err)
+	assert.Len(t, rows, 1)
+}
+
+func tagValue(tags []tag.Tag, key tag.Key) string {
+	for _, t := range tags {
+		if t.Key == key {
+			return t.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	attributeContainerName = "ContainerName"
+	attributeNodeName      = "NodeName"
+)
+
+// prometheusAttributes maps the Container Insights resource attributes to the names of the
+// kube-state-metrics labels, see Config.AddPrometheusAttributes.
+var prometheusAttributes = map[string]string{
+	attributeNamespace:     "namespace",
+	attributePodName:       "pod",
+	attributeContainerName: "container",
+	attributeNodeName:      "node",
+}
+
+// addPrometheusAttributes copies the Container Insights resource attributes of md to the
+// kube-state-metrics names. Attributes that already exist are not overwritten.
+func addPrometheusAttributes(md pdata.Metrics) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		attrs := rms.At(i).Resource().Attributes()
+		for ci, prom := range prometheusAttributes {
+			if v, ok := attrs.Get(ci); ok {
+				attrs.Insert(prom, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscontainerinsightreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestAddPrometheusAttributes(t *testing.T) {
+	md := pdata.NewMetrics()
+	newResourceMetrics(md, map[string]string{"Type": "Node", attributeNodeName: "node-1"})
+	newResourceMetrics(md, map[string]string{
+		"Type":                 "Container",
+		attributeNamespace:     "default",
+		attributePodName:       "app-1",
+		attributeContainerName: "app",
+		attributeNodeName:      "node-1",
+	})
+	newResourceMetrics(md, map[string]string{"Type": "Pod", attributePodName: "app-2", "pod": "existing"})
+
+	addPrometheusAttributes(md)
+
+	var got []map[string]string
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		attrs := map[string]string{}
+		md.ResourceMetrics().At(i).Resource().Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+			attrs[k] = v.StringVal()
+			return true
+		})
+		got = append(got, attrs)
+	}
+	assert.Equal(t, []map[string]string{
+		{"Type": "Node", attributeNodeName: "node-1", "node": "node-1"},
+		{
+			"Type":                 "Container",
+			attributeNamespace:     "default",
+			attributePodName:       "app-1",
+			attributeContainerName: "app",
+			attributeNodeName:      "node-1",
+			"namespace":            "default",
+			"pod":                  "app-1",
+			"container":            "app",
+			"node":                 "node-1",
+		},
+		{"Type": "Pod", attributePodName: "app-2", "pod": "existing"},
+	}, got)
+}
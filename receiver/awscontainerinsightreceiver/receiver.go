@@ -17,6 +17,7 @@ package awscontainerinsightreceiver
 import (
 	"context"
 	"errors"
+	"math/rand"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -29,10 +30,25 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/cadvisor"
 	hostInfo "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/host"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/k8sapiserver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/netstack"
 )
 
 var _ component.MetricsReceiver = (*awsContainerInsightReceiver)(nil)
 
+// transport is reported with the receiver's obsreport metrics
+const transport = "http"
+
+// Names of the metrics providers, used as the format of the obsreport metrics
+// and as the provider tag of the receiver's own metrics.
+const (
+	providerCadvisor     = "cadvisor"
+	providerK8sAPIServer = "k8sapiserver"
+	providerNetStack     = "netstack"
+)
+
+// defaultHostProcPath is where the procfs of the host is read from by default
+const defaultHostProcPath = "/proc"
+
 type MetricsProvider interface {
 	GetMetrics() []pdata.Metrics
 }
@@ -42,9 +58,13 @@ type awsContainerInsightReceiver struct {
 	logger       *zap.Logger
 	nextConsumer consumer.Metrics
 	config       *Config
+	// ctx is the context the metrics are exported with, the contexts of the collection cycles have a deadline
+	ctx          context.Context
 	cancel       context.CancelFunc
 	cadvisor     MetricsProvider
 	k8sapiserver MetricsProvider
+	netstack     MetricsProvider
+	podFilter    *podFilter
 }
 
 // New creates the aws container insight receiver with the given parameters.
@@ -56,40 +76,105 @@ func New(
 		return nil, componenterror.ErrNilNextConsumer
 	}
 
+	if config.CollectionJitter < 0 {
+		return nil, errors.New("collection_jitter must not be negative")
+	}
+	if config.CollectionTimeout < 0 {
+		return nil, errors.New("collection_timeout must not be negative")
+	}
+	if config.ClusterCollectionInterval < 0 {
+		return nil, errors.New("cluster_collection_interval must not be negative")
+	}
+
+	if err := validateMetricNameConvention(config.MetricNameConvention); err != nil {
+		return nil, err
+	}
+
+	podFilter, err := newPodFilter(config.Filter)
+	if err != nil {
+		return nil, err
+	}
+
 	r := &awsContainerInsightReceiver{
 		logger:       logger,
 		nextConsumer: nextConsumer,
 		config:       config,
+		ctx:          context.Background(),
+		podFilter:    podFilter,
 	}
 	return r, nil
 }
 
 // Start collecting metrics from cadvisor and k8s api server (if it is an elected leader)
 func (acir *awsContainerInsightReceiver) Start(ctx context.Context, host component.Host) error {
-	ctx, acir.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, acir.config.ID(), "http"))
+	ctx, acir.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, acir.config.ID(), transport))
+	acir.ctx = ctx
 	machineInfo := hostInfo.NewMachineInfo(acir.config.CollectionInterval, acir.logger)
 	acir.cadvisor = cadvisor.New(acir.config.ContainerOrchestrator, machineInfo, acir.logger)
 	acir.k8sapiserver = k8sapiserver.New(machineInfo, acir.logger)
+	if acir.config.EnableNetStackMetrics {
+		procPath := acir.config.HostProcPath
+		if procPath == "" {
+			procPath = defaultHostProcPath
+		}
+		acir.netstack = netstack.New(procPath, machineInfo, acir.logger)
+	}
 
 	// TODO: add more intialization code
 
-	go func() {
-		ticker := time.NewTicker(acir.config.CollectionInterval)
-		defer ticker.Stop()
+	clusterInterval := acir.config.ClusterCollectionInterval
+	if clusterInterval == 0 || clusterInterval == acir.config.CollectionInterval {
+		go acir.collectLoop(ctx, acir.config.CollectionInterval, acir.collectData)
+		return nil
+	}
 
-		for {
-			select {
-			case <-ticker.C:
-				acir.collectData(ctx)
-			case <-ctx.Done():
-				return
-			}
-		}
-	}()
+	// node and pod-level metrics are both generated from cadvisor, cluster-level metrics from the k8s api server
+	go acir.collectLoop(ctx, acir.config.CollectionInterval, acir.collectNodeData)
+	go acir.collectLoop(ctx, clusterInterval, func(ctx context.Context) error {
+		return acir.collectFrom(ctx, providerK8sAPIServer, acir.k8sapiserver)
+	})
 
 	return nil
 }
 
+// collectLoop calls collect on every interval until ctx is done. The first call is delayed by a random jitter
+// and every call is given a deadline of the collection timeout, or of the interval if none is configured.
+func (acir *awsContainerInsightReceiver) collectLoop(ctx context.Context, interval time.Duration, collect func(context.Context) error) {
+	if jitter := acir.config.CollectionJitter; jitter > 0 {
+		// the global source is not seeded, it would give the receivers on all nodes the same delay
+		delay := time.Duration(rand.New(rand.NewSource(time.Now().UnixNano())).Int63n(int64(jitter)))
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+
+	timeout := acir.config.CollectionTimeout
+	if timeout == 0 {
+		timeout = interval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			cycleCtx, cancel := context.WithTimeout(ctx, timeout)
+			if err := collect(cycleCtx); errors.Is(err, context.DeadlineExceeded) {
+				acir.logger.Warn("Collection cycle exceeded its deadline, the remaining metrics were dropped",
+					zap.Duration("timeout", timeout))
+			}
+			cancel()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // Shutdown stops the awsContainerInsightReceiver receiver.
 func (acir *awsContainerInsightReceiver) Shutdown(context.Context) error {
 	acir.cancel()
@@ -98,23 +183,75 @@ func (acir *awsContainerInsightReceiver) Shutdown(context.Context) error {
 
 // collectData collects container stats from Amazon ECS Task Metadata Endpoint
 func (acir *awsContainerInsightReceiver) collectData(ctx context.Context) error {
-	var mds []pdata.Metrics
 	if acir.cadvisor == nil && acir.k8sapiserver == nil {
 		err := errors.New("both cadvisor and k8sapiserver failed to start")
 		acir.logger.Error("Failed to collect stats", zap.Error(err))
 		return err
 	}
 
-	if acir.cadvisor != nil {
-		mds = append(mds, acir.cadvisor.GetMetrics()...)
+	if err := acir.collectNodeData(ctx); err != nil {
+		return err
 	}
+	return acir.collectFrom(ctx, providerK8sAPIServer, acir.k8sapiserver)
+}
 
-	if acir.k8sapiserver != nil {
-		mds = append(mds, acir.k8sapiserver.GetMetrics()...)
+// collectNodeData collects the node, pod and container-level metrics.
+func (acir *awsContainerInsightReceiver) collectNodeData(ctx context.Context) error {
+	if err := acir.collectFrom(ctx, providerCadvisor, acir.cadvisor); err != nil {
+		return err
 	}
+	return acir.collectFrom(ctx, providerNetStack, acir.netstack)
+}
+
+// collectFrom collects the metrics of a single provider, which is skipped if it failed to start.
+func (acir *awsContainerInsightReceiver) collectFrom(ctx context.Context, providerName string, provider MetricsProvider) error {
+	if provider == nil {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	mds := provider.GetMetrics()
+	recordCollection(ctx, providerName, time.Since(start), mds)
+
+	return acir.consumeMetrics(ctx, providerName, mds)
+}
 
+func (acir *awsContainerInsightReceiver) consumeMetrics(ctx context.Context, providerName string, mds []pdata.Metrics) error {
 	for _, md := range mds {
-		err := acir.nextConsumer.ConsumeMetrics(ctx, md)
+		recordFilteredResources(ctx, providerName, acir.podFilter.filter(md))
+		acir.config.Extractors.dropDisabledMetrics(md)
+	}
+
+	if acir.config.MaxPodSeries > 0 {
+		var aggregated int
+		mds, aggregated = limitSeries(mds, acir.config.MaxPodSeries)
+		if aggregated > 0 {
+			acir.logger.Warn("Number of pods and containers exceeds max_pod_series, the metrics of the others are aggregated",
+				zap.String("provider", providerName), zap.Int("max_pod_series", acir.config.MaxPodSeries), zap.Int("aggregated", aggregated))
+		}
+		recordOverflowResources(ctx, providerName, aggregated)
+	}
+
+	for _, md := range mds {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if acir.config.AddPrometheusAttributes {
+			addPrometheusAttributes(md)
+		}
+		if acir.config.MetricNameConvention == metricNameConventionOTel {
+			toOTelMetricNames(md)
+		}
+
+		_, numPoints := md.MetricAndDataPointCount()
+		// the collection deadline must not cancel the export of the metrics already collected
+		opCtx := obsreport.StartMetricsReceiveOp(acir.ctx, acir.config.ID(), transport, obsreport.WithLongLivedCtx())
+		err := acir.nextConsumer.ConsumeMetrics(opCtx, md)
+		obsreport.EndMetricsReceiveOp(opCtx, providerName, numPoints, err)
 		if err != nil {
 			return err
 		}
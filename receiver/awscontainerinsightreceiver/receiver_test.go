@@ -18,7 +18,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -59,6 +61,49 @@ func TestReceiverForNilConsumer(t *testing.T) {
 	require.Nil(t, metricsReceiver)
 }
 
+func TestReceiverWithInvalidFilter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Filter.ExcludePods = []string{"["}
+	metricsReceiver, err := New(
+		zap.NewNop(),
+		cfg,
+		consumertest.NewNop(),
+	)
+
+	require.Error(t, err)
+	require.Nil(t, metricsReceiver)
+}
+
+func TestReceiverWithInvalidMetricNameConvention(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricNameConvention = "prometheus"
+	metricsReceiver, err := New(
+		zap.NewNop(),
+		cfg,
+		consumertest.NewNop(),
+	)
+
+	require.Error(t, err)
+	require.Nil(t, metricsReceiver)
+}
+
+func TestReceiverWithNegativeCollectionTiming(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionJitter = -time.Second
+	_, err := New(zap.NewNop(), cfg, consumertest.NewNop())
+	require.EqualError(t, err, "collection_jitter must not be negative")
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.CollectionTimeout = -time.Second
+	_, err = New(zap.NewNop(), cfg, consumertest.NewNop())
+	require.EqualError(t, err, "collection_timeout must not be negative")
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.ClusterCollectionInterval = -time.Second
+	_, err = New(zap.NewNop(), cfg, consumertest.NewNop())
+	require.EqualError(t, err, "cluster_collection_interval must not be negative")
+}
+
 func TestCollectData(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	metricsReceiver, err := New(
@@ -93,6 +138,154 @@ func (c *MockCadvisor) GetMetrics() []pdata.Metrics {
 	return []pdata.Metrics{md}
 }
 
+//Mock k8sapiserver
+type MockK8sAPIServer struct {
+}
+
+func (k *MockK8sAPIServer) GetMetrics() []pdata.Metrics {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+	return []pdata.Metrics{md}
+}
+
+func TestCollectLoop(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	metricsReceiver, err := New(
+		zap.NewNop(),
+		cfg,
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+
+	r := metricsReceiver.(*awsContainerInsightReceiver)
+	ctx, cancel := context.WithCancel(context.Background())
+	collected := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		r.collectLoop(ctx, 10*time.Millisecond, func(context.Context) error {
+			select {
+			case collected <- struct{}{}:
+			default:
+			}
+			return nil
+		})
+		close(done)
+	}()
+
+	select {
+	case <-collected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("metrics were not collected")
+	}
+	cancel()
+	<-done
+}
+
+func TestCollectLoopWithJitterAndTimeout(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionJitter = 10 * time.Millisecond
+	cfg.CollectionTimeout = time.Minute
+	metricsReceiver, err := New(
+		zap.NewNop(),
+		cfg,
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+
+	r := metricsReceiver.(*awsContainerInsightReceiver)
+	ctx, cancel := context.WithCancel(context.Background())
+	deadlines := make(chan time.Time, 1)
+	done := make(chan struct{})
+	go func() {
+		r.collectLoop(ctx, 10*time.Millisecond, func(ctx context.Context) error {
+			deadline, _ := ctx.Deadline()
+			select {
+			case deadlines <- deadline:
+			default:
+			}
+			return nil
+		})
+		close(done)
+	}()
+
+	select {
+	case deadline := <-deadlines:
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+	case <-time.After(5 * time.Second):
+		t.Fatal("metrics were not collected")
+	}
+	cancel()
+	<-done
+}
+
+func TestCollectFromAfterDeadline(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	sink := new(consumertest.MetricsSink)
+	metricsReceiver, err := New(
+		zap.NewNop(),
+		cfg,
+		sink,
+	)
+	require.NoError(t, err)
+
+	r := metricsReceiver.(*awsContainerInsightReceiver)
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	err = r.collectFrom(ctx, providerK8sAPIServer, &MockK8sAPIServer{})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Empty(t, sink.AllMetrics())
+
+	err = r.consumeMetrics(ctx, providerK8sAPIServer, (&MockK8sAPIServer{}).GetMetrics())
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestCollectFrom(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	sink := new(consumertest.MetricsSink)
+	metricsReceiver, err := New(
+		zap.NewNop(),
+		cfg,
+		sink,
+	)
+	require.NoError(t, err)
+
+	r := metricsReceiver.(*awsContainerInsightReceiver)
+	ctx := context.Background()
+	require.NoError(t, r.collectFrom(ctx, providerK8sAPIServer, nil))
+	assert.Empty(t, sink.AllMetrics())
+
+	require.NoError(t, r.collectFrom(ctx, providerK8sAPIServer, &MockK8sAPIServer{}))
+	assert.Len(t, sink.AllMetrics(), 1)
+}
+
+// deadlineConsumer records whether the contexts the metrics are consumed with have a deadline.
+type deadlineConsumer struct {
+	consumertest.MetricsSink
+	hasDeadline bool
+}
+
+func (c *deadlineConsumer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if _, ok := ctx.Deadline(); ok {
+		c.hasDeadline = true
+	}
+	return c.MetricsSink.ConsumeMetrics(ctx, md)
+}
+
+func TestConsumeMetricsWithoutCollectionDeadline(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	next := &deadlineConsumer{}
+	metricsReceiver, err := New(zap.NewNop(), cfg, next)
+	require.NoError(t, err)
+
+	r := metricsReceiver.(*awsContainerInsightReceiver)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	require.NoError(t, r.collectFrom(ctx, providerK8sAPIServer, &MockK8sAPIServer{}))
+	assert.Len(t, next.AllMetrics(), 1)
+	assert.False(t, next.hasDeadline)
+}
+
 func TestCollectDataWithErrConsumer(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	metricsReceiver, err := New(